@@ -0,0 +1,2 @@
+# DNS zones can be imported by their ID or their domain.
+terraform import netlify_dns_zone.example example.com
@@ -0,0 +1,2 @@
+# Sites can be imported by their ID or their name.
+terraform import netlify_site.example my-site-name
@@ -0,0 +1,229 @@
+package netlify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/netlify/open-api/v2/go/models"
+	"github.com/netlify/open-api/v2/go/plumbing/operations"
+)
+
+// The page where users authorize a login ticket.
+const authorizeURL = "https://app.netlify.com/authorize?response_type=ticket&ticket="
+
+// Returns the auth info for API requests, authenticated by the token if set.
+func newAuthInfo(token string) runtime.ClientAuthInfoWriter {
+	return runtime.ClientAuthInfoWriterFunc(func(r runtime.ClientRequest, _ strfmt.Registry) error {
+		r.SetHeaderParam("User-Agent", "Terraform")
+		if token != "" {
+			r.SetHeaderParam("Authorization", "Bearer "+token)
+		}
+		return nil
+	})
+}
+
+// The credentials cached for a client ID. Only one of the fields is set: the
+// ticket while waiting for the user to authorize it, the token afterwards.
+type cachedCredentials struct {
+	AccessToken string `json:"access_token,omitempty"`
+	TicketID    string `json:"ticket_id,omitempty"`
+}
+
+// Returns the default path of the credentials file.
+func defaultCredentialsFile() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "terraform-provider-netlify", "credentials.json"), nil
+}
+
+// Reads the credentials file, which maps client IDs to their credentials.
+// A missing file has no credentials.
+func readCredentials(path string) (map[string]cachedCredentials, error) {
+	creds := map[string]cachedCredentials{}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return creds, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(b, &creds); err != nil {
+		return nil, fmt.Errorf("Error parsing credentials file %s: %s", path, err)
+	}
+	return creds, nil
+}
+
+// Stores the credentials of a client ID, keeping those of other client IDs.
+func writeCredentials(path, clientID string, cred cachedCredentials) error {
+	creds, err := readCredentials(path)
+	if err != nil {
+		return err
+	}
+	creds[clientID] = cred
+
+	b, err := json.MarshalIndent(creds, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0600)
+}
+
+// How often and how long a login ticket is polled until it is authorized.
+var (
+	ticketPollInterval = 2 * time.Second
+	ticketWaitTimeout  = 5 * time.Minute
+)
+
+// Logs in with a ticket of the OAuth application, returning an access token.
+//
+// Terraform cannot show a link while the provider is being configured, so the
+// first run creates a ticket and fails with the link to authorize it. The next
+// run waits for the ticket to be authorized and exchanges it for a token,
+// which is cached in the credentials file and reused by later runs.
+func loginWithTicket(c context.Context, meta *Meta, clientID, credentialsFile string) (string, error) {
+	creds, err := readCredentials(credentialsFile)
+	if err != nil {
+		return "", err
+	}
+
+	cred := creds[clientID]
+	if cred.AccessToken != "" {
+		return cred.AccessToken, nil
+	}
+
+	var ticket *models.Ticket
+	if cred.TicketID != "" {
+		ticket, err = meta.Netlify.ShowTicket(meta.porcelainContext(c), cred.TicketID)
+		// The ticket has expired, so a new one is created below.
+		if err != nil && !isNotFound(err) {
+			return "", fmt.Errorf("Error reading login ticket %s: %s", cred.TicketID, err)
+		}
+	}
+
+	if ticket == nil {
+		ticket, err = meta.Netlify.CreateTicket(meta.porcelainContext(c), clientID)
+		if err != nil {
+			return "", fmt.Errorf("Error creating login ticket: %s", err)
+		}
+		if err := writeCredentials(credentialsFile, clientID, cachedCredentials{TicketID: ticket.ID}); err != nil {
+			return "", fmt.Errorf("Error caching login ticket: %s", err)
+		}
+		return "", ticketAuthorizationError(ticket.ID)
+	}
+
+	if !ticket.Authorized {
+		ticket, err = waitUntilTicketAuthorized(c, meta, ticket.ID)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	token, err := meta.Netlify.ExchangeTicket(meta.porcelainContext(c), ticket.ID)
+	if err != nil {
+		return "", fmt.Errorf("Error exchanging login ticket %s: %s", ticket.ID, err)
+	}
+
+	if err := writeCredentials(credentialsFile, clientID, cachedCredentials{AccessToken: token.AccessToken}); err != nil {
+		return "", fmt.Errorf("Error caching access token: %s", err)
+	}
+	return token.AccessToken, nil
+}
+
+// Polls a ticket until it is authorized, failing with the link to authorize
+// it once the context ends or the wait times out. The porcelain's
+// WaitUntilTicketAuthorized is not used, as it keeps polling after the
+// context ends.
+func waitUntilTicketAuthorized(c context.Context, meta *Meta, ticketID string) (*models.Ticket, error) {
+	c, cancel := context.WithTimeout(c, ticketWaitTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(ticketPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.Done():
+			return nil, ticketAuthorizationError(ticketID)
+		case <-ticker.C:
+		}
+
+		params := operations.NewShowTicketParamsWithContext(c)
+		params.TicketID = ticketID
+		resp, err := meta.Netlify.Operations.ShowTicket(params, meta.AuthInfo)
+		if err != nil {
+			if c.Err() != nil {
+				return nil, ticketAuthorizationError(ticketID)
+			}
+			return nil, fmt.Errorf("Error reading login ticket %s: %s", ticketID, err)
+		}
+		if resp.Payload.Authorized {
+			return resp.Payload, nil
+		}
+	}
+}
+
+func ticketAuthorizationError(ticketID string) error {
+	return fmt.Errorf("Authorize Terraform to access your Netlify account by opening %s%s, then run Terraform again", authorizeURL, ticketID)
+}
+
+// Returns the path of the Netlify CLI's config file.
+func defaultCLIConfigFile() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "netlify", "config.json"), nil
+}
+
+// The parts of the Netlify CLI's config file holding the logged in users.
+type cliConfig struct {
+	UserID string `json:"userId"`
+	Users  map[string]struct {
+		Auth struct {
+			Token string `json:"token"`
+		} `json:"auth"`
+	} `json:"users"`
+}
+
+// Returns the token of a user logged in with the Netlify CLI, or of the
+// current CLI user if userID is empty. Without a config file or a current
+// user, no token is returned.
+func readCLIToken(path, userID string) (string, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && userID == "" {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("Error reading Netlify CLI config: %s", err)
+	}
+
+	var config cliConfig
+	if err := json.Unmarshal(b, &config); err != nil {
+		return "", fmt.Errorf("Error parsing Netlify CLI config %s: %s", path, err)
+	}
+
+	if userID == "" {
+		if config.UserID == "" {
+			return "", nil
+		}
+		userID = config.UserID
+	}
+
+	user, ok := config.Users[userID]
+	if !ok || user.Auth.Token == "" {
+		return "", fmt.Errorf("User %s is not logged in with the Netlify CLI", userID)
+	}
+	return user.Auth.Token, nil
+}
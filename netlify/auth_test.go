@@ -0,0 +1,128 @@
+package netlify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLoginWithTicket(t *testing.T) {
+	var authorized atomic.Bool
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/oauth/tickets":
+			if r.URL.Query().Get("client_id") != "client" {
+				t.Errorf("unexpected client_id %q", r.URL.Query().Get("client_id"))
+			}
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": "ticket"})
+		case r.Method == "GET" && r.URL.Path == "/oauth/tickets/ticket":
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": "ticket", "authorized": authorized.Load()})
+		case r.Method == "POST" && r.URL.Path == "/oauth/tickets/ticket/exchange":
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "token"})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	config := Config{
+		BaseURL:         server.URL,
+		ClientID:        "client",
+		CredentialsFile: filepath.Join(t.TempDir(), "credentials.json"),
+	}
+
+	ticketPollInterval = 10 * time.Millisecond
+	defer func() { ticketPollInterval = 2 * time.Second }()
+
+	// The first run creates a ticket and fails with the link to authorize it.
+	_, err := config.Client(context.Background())
+	if err == nil || !strings.Contains(err.Error(), authorizeURL+"ticket") {
+		t.Fatalf("expected an error with the authorize link, got %v", err)
+	}
+
+	// The next run waits for the ticket, failing with the link again if it
+	// ends before the ticket is authorized. Polling stops with it.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = config.Client(ctx)
+	if err == nil || !strings.Contains(err.Error(), authorizeURL+"ticket") {
+		t.Fatalf("expected an error with the authorize link, got %v", err)
+	}
+	polls := requests.Load()
+	time.Sleep(100 * time.Millisecond)
+	if n := requests.Load(); n != polls {
+		t.Fatalf("expected polling to stop with the run, got %d more requests", n-polls)
+	}
+
+	// Once authorized, the ticket is exchanged for a token.
+	time.AfterFunc(100*time.Millisecond, func() { authorized.Store(true) })
+	if _, err := config.Client(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	creds, err := readCredentials(config.CredentialsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if creds["client"].AccessToken != "token" {
+		t.Fatalf("expected the token to be cached, got %+v", creds)
+	}
+
+	// Later runs use the cached token.
+	requests.Store(0)
+	if _, err := config.Client(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if n := requests.Load(); n != 0 {
+		t.Fatalf("expected no requests with a cached token, got %d", n)
+	}
+}
+
+func TestReadCLIToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if token, err := readCLIToken(path, ""); token != "" || err != nil {
+		t.Fatalf("expected no token without a config file, got %q %v", token, err)
+	}
+	if _, err := readCLIToken(path, "a"); err == nil {
+		t.Fatal("expected an error for a user without a config file")
+	}
+
+	config := `{
+		"userId": "a",
+		"users": {
+			"a": {"id": "a", "auth": {"token": "token-a"}},
+			"b": {"id": "b", "auth": {"token": "token-b"}}
+		}
+	}`
+	if err := os.WriteFile(path, []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		userID  string
+		want    string
+		wantErr bool
+	}{
+		{"", "token-a", false},
+		{"b", "token-b", false},
+		{"c", "", true},
+	}
+	for _, tc := range cases {
+		token, err := readCLIToken(path, tc.userID)
+		if token != tc.want || (err != nil) != tc.wantErr {
+			t.Errorf("readCLIToken(%q): expected %q, got %q %v", tc.userID, tc.want, token, err)
+		}
+	}
+}
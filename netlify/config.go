@@ -1,8 +1,11 @@
 package netlify
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/go-openapi/runtime"
 	openapiClient "github.com/go-openapi/runtime/client"
@@ -10,21 +13,64 @@ import (
 	"github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/netlify/open-api/v2/go/porcelain"
+	porcelainContext "github.com/netlify/open-api/v2/go/porcelain/context"
 )
 
 type Config struct {
 	Token   string
 	BaseURL string
+
+	// ClientID is the OAuth application used to log in when Token is not
+	// set, with the resulting token cached in CredentialsFile.
+	ClientID        string
+	CredentialsFile string
+
+	// CLIConfigFile is the Netlify CLI's config file, whose token for UserID
+	// (or the CLI's current user) is used when neither Token nor ClientID is
+	// set.
+	CLIConfigFile string
+	UserID        string
+
+	// DefaultAccountSlug is the account used by resources that are not given
+	// one.
+	DefaultAccountSlug string
+
+	// MaxRetries is how often a request that was rate limited or failed
+	// with a server error is retried.
+	MaxRetries int
+
+	// RequestTimeout limits how long a request may take, including retries.
+	RequestTimeout time.Duration
+
+	// SiteLocks are shared by the clients of the muxed providers. New locks
+	// are created if it is nil.
+	SiteLocks *siteLocks
 }
 
 // Meta is the returned meta struct.
 type Meta struct {
 	Netlify  *porcelain.Netlify
 	AuthInfo runtime.ClientAuthInfoWriter
+
+	// HTTPClient is used for requests outside of the Netlify API client,
+	// such as calling build hooks.
+	HTTPClient *http.Client
+
+	// SiteLocks serialises read-modify-write changes to site settings.
+	SiteLocks *siteLocks
+
+	// DefaultAccountSlug is the account used by resources that are not given
+	// one, if set.
+	DefaultAccountSlug string
+}
+
+// Returns a context carrying our auth info, as the porcelain client expects.
+func (m *Meta) porcelainContext(ctx context.Context) porcelainContext.Context {
+	return porcelainContext.WithAuthInfo(ctx, m.AuthInfo)
 }
 
 // Client configures and returns a fully initialized NetlifyClient
-func (c *Config) Client() (interface{}, error) {
+func (c *Config) Client(ctx context.Context) (interface{}, error) {
 	u, err := url.Parse(c.BaseURL)
 	if err != nil {
 		return nil, fmt.Errorf("Error parsing base_url: %s", err)
@@ -35,21 +81,54 @@ func (c *Config) Client() (interface{}, error) {
 		u.Scheme = "https"
 	}
 
+	httpClient := cleanhttp.DefaultClient()
+	httpClient.Transport = newRateLimitTransport(
+		logging.NewTransport("Netlify", httpClient.Transport), c.MaxRetries)
+
 	// Create the OpenAPI client with our custom roundtripper.
 	client := openapiClient.NewWithClient(
 		u.Host, u.Path, []string{u.Scheme},
-		cleanhttp.DefaultClient())
-	client.Transport = logging.NewTransport("Netlify", client.Transport)
-
-	// Setup our auth
-	authInfo := runtime.ClientAuthInfoWriterFunc(func(r runtime.ClientRequest, _ strfmt.Registry) error {
-		r.SetHeaderParam("User-Agent", "Terraform")
-		r.SetHeaderParam("Authorization", "Bearer "+c.Token)
-		return nil
-	})
-
-	return &Meta{
-		Netlify:  porcelain.NewRetryable(client, strfmt.Default, porcelain.DefaultRetryAttempts),
-		AuthInfo: authInfo,
-	}, nil
+		httpClient)
+
+	// Requests outside of the API neither go through the logging transport,
+	// as their URLs may be secret, nor count towards the API's rate limit.
+	// API operations are limited by the timeout transport instead, which
+	// also covers their retries.
+	plainClient := cleanhttp.DefaultClient()
+	plainClient.Timeout = c.RequestTimeout
+
+	locks := c.SiteLocks
+	if locks == nil {
+		locks = newSiteLocks()
+	}
+
+	meta := &Meta{
+		Netlify:  porcelain.New(&apiErrorTransport{next: &timeoutTransport{next: client, timeout: c.RequestTimeout}}, strfmt.Default),
+		AuthInfo: newAuthInfo(c.Token),
+
+		HTTPClient: plainClient,
+		SiteLocks:  locks,
+
+		DefaultAccountSlug: c.DefaultAccountSlug,
+	}
+
+	// Without a token, log in through the OAuth application or fall back to
+	// the Netlify CLI's login.
+	if c.Token == "" {
+		var token string
+		if c.ClientID != "" {
+			token, err = loginWithTicket(ctx, meta, c.ClientID, c.CredentialsFile)
+		} else {
+			token, err = readCLIToken(c.CLIConfigFile, c.UserID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if token == "" {
+			return nil, fmt.Errorf("No Netlify token found: set token or client_id, or log in with the Netlify CLI")
+		}
+		meta.AuthInfo = newAuthInfo(token)
+	}
+
+	return meta, nil
 }
@@ -0,0 +1,98 @@
+package netlify
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/netlify/open-api/v2/go/plumbing/operations"
+)
+
+func dataSourceBuildHooks() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists the build hooks of a site, optionally filtered by branch and title.",
+		ReadContext: dataSourceBuildHooksRead,
+		Schema: map[string]*schema.Schema{
+			"site_id": {
+				Description: "The ID of the site.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"branch": {
+				Description: "If provided, only hooks building this branch are returned.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"title": {
+				Description: "If provided, only hooks with this title are returned.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"build_hooks": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"title": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"branch": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"url": {
+							Type:      schema.TypeString,
+							Computed:  true,
+							Sensitive: true,
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceBuildHooksRead(ctx context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	meta := metaRaw.(*Meta)
+	params := operations.NewListSiteBuildHooksParamsWithContext(ctx)
+	params.SiteID = d.Get("site_id").(string)
+	resp, err := meta.Netlify.Operations.ListSiteBuildHooks(params, meta.AuthInfo)
+	if err != nil {
+		return apiErrorDiags(err, cty.GetAttrPath("site_id"))
+	}
+
+	branch := d.Get("branch").(string)
+	title := d.Get("title").(string)
+	hooks := []interface{}{}
+	for _, hook := range resp.Payload {
+		if branch != "" && hook.Branch != branch {
+			continue
+		}
+		if title != "" && hook.Title != title {
+			continue
+		}
+
+		hooks = append(hooks, map[string]interface{}{
+			"id":         hook.ID,
+			"title":      hook.Title,
+			"branch":     hook.Branch,
+			"url":        hook.URL,
+			"created_at": hook.CreatedAt,
+		})
+	}
+
+	d.SetId(params.SiteID)
+	d.Set("build_hooks", hooks)
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package netlify
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDSBuildHooks(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckBuildHookDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDSBuildHooksConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.netlify_build_hooks.test", "build_hooks.#", "1"),
+					resource.TestCheckResourceAttrPair("data.netlify_build_hooks.test", "build_hooks.0.id", "netlify_build_hook.master", "id"),
+					resource.TestCheckResourceAttrPair("data.netlify_build_hooks.test", "build_hooks.0.url", "netlify_build_hook.master", "url"),
+				),
+			},
+		},
+	})
+}
+
+var testAccDSBuildHooksConfig = `
+resource "netlify_site" "test" {}
+
+resource "netlify_build_hook" "master" {
+	site_id = netlify_site.test.id
+	branch = "master"
+	title = "tubes"
+}
+
+resource "netlify_build_hook" "other" {
+	site_id = netlify_site.test.id
+	branch = "other"
+	title = "tubes"
+}
+
+data "netlify_build_hooks" "test" {
+	site_id = netlify_site.test.id
+	branch = "master"
+	title = "tubes"
+
+	depends_on = [netlify_build_hook.master, netlify_build_hook.other]
+}
+`
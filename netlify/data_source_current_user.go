@@ -0,0 +1,141 @@
+package netlify
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/netlify/open-api/v2/go/models"
+	"github.com/netlify/open-api/v2/go/plumbing/operations"
+)
+
+func dataSourceCurrentUser() *schema.Resource {
+	return &schema.Resource{
+		Description: "Returns the user the provider is authenticated as, and the accounts they are a member of.",
+		ReadContext: dataSourceCurrentUserRead,
+		Schema: map[string]*schema.Schema{
+			"email": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"full_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"avatar_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"login_providers": {
+				Description: "The providers the user can log in with, e.g. `github`.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"accounts": {
+				Description: "The accounts the user is a member of.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"slug": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCurrentUserRead(ctx context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	meta := metaRaw.(*Meta)
+	user, err := getCurrentUser(ctx, meta)
+	if err != nil {
+		return apiErrorDiags(err, nil)
+	}
+
+	resp, err := meta.Netlify.Operations.ListAccountsForUser(operations.NewListAccountsForUserParamsWithContext(ctx), meta.AuthInfo)
+	if err != nil {
+		return apiErrorDiags(err, nil)
+	}
+
+	accounts := []interface{}{}
+	for _, account := range resp.Payload {
+		accounts = append(accounts, map[string]interface{}{
+			"id":   account.ID,
+			"name": account.Name,
+			"slug": account.Slug,
+			"type": account.TypeName,
+		})
+	}
+
+	d.SetId(user.ID)
+	d.Set("email", user.Email)
+	d.Set("full_name", user.FullName)
+	d.Set("avatar_url", user.AvatarURL)
+	d.Set("login_providers", user.LoginProviders)
+	d.Set("accounts", accounts)
+
+	return nil
+}
+
+// Returns the user the provider is authenticated as. The API returns a single
+// user, while the generated client expects a list, so the response is read
+// here instead.
+func getCurrentUser(ctx context.Context, meta *Meta) (*models.User, error) {
+	result, err := meta.Netlify.Transport.Submit(&runtime.ClientOperation{
+		ID:                 "getCurrentUser",
+		Method:             "GET",
+		PathPattern:        "/user",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             runtime.ClientRequestWriterFunc(func(runtime.ClientRequest, strfmt.Registry) error { return nil }),
+		Reader:             &currentUserReader{},
+		AuthInfo:           meta.AuthInfo,
+		Context:            ctx,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	user, ok := result.(*models.User)
+	if !ok {
+		return nil, fmt.Errorf("Unexpected response reading the current user: %v", result)
+	}
+	return user, nil
+}
+
+type currentUserReader struct{}
+
+func (r *currentUserReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	if response.Code() != 200 {
+		return (&operations.GetCurrentUserReader{}).ReadResponse(response, consumer)
+	}
+
+	user := &models.User{}
+	if err := consumer.Consume(response.Body(), user); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return user, nil
+}
@@ -0,0 +1,53 @@
+package netlify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDSCurrentUser(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDSCurrentUserConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.netlify_current_user.test", "id"),
+					resource.TestCheckResourceAttrSet("data.netlify_current_user.test", "email"),
+					resource.TestCheckResourceAttrSet("data.netlify_current_user.test", "accounts.0.slug"),
+				),
+			},
+		},
+	})
+}
+
+func TestGetCurrentUser(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "user", "email": "user@example.com", "full_name": "A User"}`))
+	}))
+	defer server.Close()
+
+	config := Config{Token: "token", BaseURL: server.URL}
+	meta, err := config.Client(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user, err := getCurrentUser(context.Background(), meta.(*Meta))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.ID != "user" || user.Email != "user@example.com" || user.FullName != "A User" {
+		t.Fatalf("unexpected user %+v", user)
+	}
+}
+
+var testAccDSCurrentUserConfig = `
+data "netlify_current_user" "test" {}
+`
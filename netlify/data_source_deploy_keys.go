@@ -0,0 +1,60 @@
+package netlify
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/netlify/open-api/v2/go/plumbing/operations"
+)
+
+func dataSourceDeployKeys() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists the deploy keys of the account.",
+		ReadContext: dataSourceDeployKeysRead,
+		Schema: map[string]*schema.Schema{
+			"deploy_keys": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"public_key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDeployKeysRead(ctx context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	meta := metaRaw.(*Meta)
+	resp, err := meta.Netlify.Operations.ListDeployKeys(operations.NewListDeployKeysParamsWithContext(ctx), meta.AuthInfo)
+	if err != nil {
+		return apiErrorDiags(err, nil)
+	}
+
+	keys := []interface{}{}
+	for _, key := range resp.Payload {
+		keys = append(keys, map[string]interface{}{
+			"id":         key.ID,
+			"public_key": key.PublicKey,
+			"created_at": key.CreatedAt,
+		})
+	}
+
+	d.SetId("deploy_keys")
+	d.Set("deploy_keys", keys)
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package netlify
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDSDeployKeys(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckDeployKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDSDeployKeysConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.netlify_deploy_keys.test", "deploy_keys.#"),
+				),
+			},
+		},
+	})
+}
+
+var testAccDSDeployKeysConfig = `
+resource "netlify_deploy_key" "test" {}
+
+data "netlify_deploy_keys" "test" {
+	depends_on = [netlify_deploy_key.test]
+}
+`
@@ -0,0 +1,279 @@
+package netlify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/netlify/open-api/v2/go/models"
+	"github.com/netlify/open-api/v2/go/plumbing/operations"
+)
+
+// The page size used when listing submissions.
+const submissionsPerPage = 100
+
+func dataSourceFormSubmissions() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists the submissions of a form, or of all forms on a site.",
+		ReadContext: dataSourceFormSubmissionsRead,
+		Schema: map[string]*schema.Schema{
+			"site_id": {
+				Description:  "The ID of the site to list submissions of all forms for. Required if form_id is not specified.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"site_id", "form_id"},
+			},
+			"form_id": {
+				Description:  "The ID of the form to list submissions for. Required if site_id is not specified.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"site_id", "form_id"},
+			},
+			"created_after": {
+				Description:  "Only include submissions created after this RFC 3339 timestamp.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"created_before": {
+				Description:  "Only include submissions created before this RFC 3339 timestamp.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"fields": {
+				Description: "If provided, only these fields are included in the `data` of each submission. The personal attributes of a submission, e.g. `email`, are only set if listed too.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"submissions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"number": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"email": {
+							Type:      schema.TypeString,
+							Computed:  true,
+							Sensitive: true,
+						},
+						"name": {
+							Type:      schema.TypeString,
+							Computed:  true,
+							Sensitive: true,
+						},
+						"first_name": {
+							Type:      schema.TypeString,
+							Computed:  true,
+							Sensitive: true,
+						},
+						"last_name": {
+							Type:      schema.TypeString,
+							Computed:  true,
+							Sensitive: true,
+						},
+						"company": {
+							Type:      schema.TypeString,
+							Computed:  true,
+							Sensitive: true,
+						},
+						"summary": {
+							Type:      schema.TypeString,
+							Computed:  true,
+							Sensitive: true,
+						},
+						"body": {
+							Type:      schema.TypeString,
+							Computed:  true,
+							Sensitive: true,
+						},
+						"site_url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"data": {
+							Type:      schema.TypeMap,
+							Computed:  true,
+							Sensitive: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceFormSubmissionsRead(ctx context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	meta := metaRaw.(*Meta)
+	filter, err := submissionFilterFromResourceData(d)
+	if err != nil {
+		return apiErrorDiags(err, nil)
+	}
+
+	siteID := d.Get("site_id").(string)
+	formID := d.Get("form_id").(string)
+	submissions, err := listSubmissions(ctx, meta, siteID, formID, filter)
+	if err != nil {
+		return apiErrorDiags(err, nil)
+	}
+
+	var fields map[string]bool
+	if v, ok := d.GetOk("fields"); ok {
+		fields = map[string]bool{}
+		for _, f := range v.(*schema.Set).List() {
+			fields[f.(string)] = true
+		}
+	}
+
+	result := []interface{}{}
+	for _, s := range submissions {
+		data := map[string]interface{}{}
+		if m, ok := s.Data.(map[string]interface{}); ok {
+			for k, v := range m {
+				if fields == nil || fields[k] {
+					data[k] = fmt.Sprint(v)
+				}
+			}
+		}
+
+		submission := map[string]interface{}{
+			"id":         s.ID,
+			"number":     int(s.Number),
+			"site_url":   s.SiteURL,
+			"created_at": s.CreatedAt,
+			"data":       data,
+		}
+		personal := map[string]string{
+			"email":      s.Email,
+			"name":       s.Name,
+			"first_name": s.FirstName,
+			"last_name":  s.LastName,
+			"company":    s.Company,
+			"summary":    s.Summary,
+			"body":       s.Body,
+		}
+		for k, v := range personal {
+			if fields == nil || fields[k] {
+				submission[k] = v
+			}
+		}
+		result = append(result, submission)
+	}
+
+	if formID != "" {
+		d.SetId(formID)
+	} else {
+		d.SetId(siteID)
+	}
+	d.Set("submissions", result)
+
+	return nil
+}
+
+// Restricts submissions to those created within a time range. Zero times
+// are unbounded.
+type submissionFilter struct {
+	after  time.Time
+	before time.Time
+}
+
+func (f submissionFilter) matches(s *models.Submission) (bool, error) {
+	if f.after.IsZero() && f.before.IsZero() {
+		return true, nil
+	}
+
+	createdAt, err := time.Parse(time.RFC3339, s.CreatedAt)
+	if err != nil {
+		return false, fmt.Errorf("Error parsing created_at of submission %s: %s", s.ID, err)
+	}
+
+	if !f.after.IsZero() && !createdAt.After(f.after) {
+		return false, nil
+	}
+	if !f.before.IsZero() && !createdAt.Before(f.before) {
+		return false, nil
+	}
+	return true, nil
+}
+
+// Reads the created_after and created_before attributes.
+func submissionFilterFromResourceData(d *schema.ResourceData) (submissionFilter, error) {
+	var filter submissionFilter
+	var err error
+	if v, ok := d.GetOk("created_after"); ok {
+		if filter.after, err = time.Parse(time.RFC3339, v.(string)); err != nil {
+			return filter, err
+		}
+	}
+	if v, ok := d.GetOk("created_before"); ok {
+		if filter.before, err = time.Parse(time.RFC3339, v.(string)); err != nil {
+			return filter, err
+		}
+	}
+	return filter, nil
+}
+
+// Lists the submissions of a form, or of all forms of a site if formID is
+// empty, following pages until all submissions have been read.
+func listSubmissions(c context.Context, meta *Meta, siteID, formID string, filter submissionFilter) ([]*models.Submission, error) {
+	var result []*models.Submission
+	perPage := int32(submissionsPerPage)
+	for page := int32(1); ; page++ {
+		var submissions []*models.Submission
+		if formID != "" {
+			params := operations.NewListFormSubmissionsParamsWithContext(c)
+			params.FormID = formID
+			params.Page = &page
+			params.PerPage = &perPage
+			resp, err := meta.Netlify.Operations.ListFormSubmissions(params, meta.AuthInfo)
+			if err != nil {
+				return nil, err
+			}
+			submissions = resp.Payload
+		} else {
+			params := operations.NewListSiteSubmissionsParamsWithContext(c)
+			params.SiteID = siteID
+			params.Page = &page
+			params.PerPage = &perPage
+			resp, err := meta.Netlify.Operations.ListSiteSubmissions(params, meta.AuthInfo)
+			if err != nil {
+				return nil, err
+			}
+			submissions = resp.Payload
+		}
+
+		for _, s := range submissions {
+			ok, err := filter.matches(s)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				result = append(result, s)
+			}
+		}
+
+		if len(submissions) < submissionsPerPage {
+			return result, nil
+		}
+	}
+}
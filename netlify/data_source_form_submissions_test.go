@@ -0,0 +1,111 @@
+package netlify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/netlify/open-api/v2/go/models"
+)
+
+func TestAccDSFormSubmissions(t *testing.T) {
+	testAccSkipFake(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckSiteDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDSFormSubmissionsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.netlify_form_submissions.test", "submissions.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestDSFormSubmissions_fields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id": "1", "email": "jane@example.com", "name": "Jane", "data": {"email": "jane@example.com", "message": "Hello"}}]`))
+	}))
+	defer server.Close()
+
+	config := Config{Token: "token", BaseURL: server.URL}
+	meta, err := config.Client(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := dataSourceFormSubmissions()
+	d := r.TestResourceData()
+	d.Set("form_id", "form")
+	d.Set("fields", []interface{}{"message", "name"})
+	if diags := r.ReadContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected diagnostics %v", diags)
+	}
+
+	want := map[string]string{
+		"submissions.0.name":         "Jane",
+		"submissions.0.email":        "",
+		"submissions.0.data.message": "Hello",
+		"submissions.0.data.email":   "",
+		"submissions.0.data.%":       "1",
+		"submissions.0.first_name":   "",
+	}
+	for k, v := range want {
+		if got := d.Get(k); fmt.Sprint(got) != v {
+			t.Errorf("expected %s to be %q, got %q", k, v, got)
+		}
+	}
+}
+
+func TestSubmissionFilter(t *testing.T) {
+	at := func(s string) time.Time {
+		v, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+	submission := &models.Submission{ID: "1", CreatedAt: "2022-06-15T12:00:00Z"}
+
+	cases := []struct {
+		name   string
+		filter submissionFilter
+		want   bool
+	}{
+		{"unbounded", submissionFilter{}, true},
+		{"after", submissionFilter{after: at("2022-06-01T00:00:00Z")}, true},
+		{"not after", submissionFilter{after: at("2022-07-01T00:00:00Z")}, false},
+		{"before", submissionFilter{before: at("2022-07-01T00:00:00Z")}, true},
+		{"not before", submissionFilter{before: at("2022-06-01T00:00:00Z")}, false},
+		{"within", submissionFilter{after: at("2022-06-01T00:00:00Z"), before: at("2022-07-01T00:00:00Z")}, true},
+	}
+
+	for _, tc := range cases {
+		got, err := tc.filter.matches(submission)
+		if err != nil {
+			t.Fatalf("%s: %s", tc.name, err)
+		}
+		if got != tc.want {
+			t.Errorf("%s: expected %t, got %t", tc.name, tc.want, got)
+		}
+	}
+}
+
+var testAccDSFormSubmissionsConfig = `
+resource "netlify_site" "test" {}
+
+data "netlify_form_submissions" "test" {
+	site_id = netlify_site.test.id
+	created_after = "2020-01-01T00:00:00Z"
+	fields = ["email"]
+}
+`
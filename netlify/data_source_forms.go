@@ -0,0 +1,87 @@
+package netlify
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceForms() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists the forms Netlify has detected on a site.",
+		ReadContext: dataSourceFormsRead,
+		Schema: map[string]*schema.Schema{
+			"site_id": {
+				Description: "The ID of the site.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"forms": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"paths": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"fields": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"submission_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceFormsRead(ctx context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	meta := metaRaw.(*Meta)
+	siteID := d.Get("site_id").(string)
+	forms, err := meta.Netlify.ListFormsBySiteId(meta.porcelainContext(ctx), siteID)
+	if err != nil {
+		return apiErrorDiags(err, cty.GetAttrPath("site_id"))
+	}
+
+	result := []interface{}{}
+	for _, form := range forms {
+		result = append(result, map[string]interface{}{
+			"id":               form.ID,
+			"name":             form.Name,
+			"paths":            form.Paths,
+			"fields":           formFieldNames(form),
+			"submission_count": int(form.SubmissionCount),
+			"created_at":       form.CreatedAt,
+		})
+	}
+
+	d.SetId(siteID)
+	d.Set("forms", result)
+
+	return nil
+}
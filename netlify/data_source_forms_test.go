@@ -0,0 +1,32 @@
+package netlify
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDSForms(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckSiteDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDSFormsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.netlify_forms.test", "id", "netlify_site.test", "id"),
+					resource.TestCheckResourceAttr("data.netlify_forms.test", "forms.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+var testAccDSFormsConfig = `
+resource "netlify_site" "test" {}
+
+data "netlify_forms" "test" {
+	site_id = netlify_site.test.id
+}
+`
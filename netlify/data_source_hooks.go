@@ -0,0 +1,103 @@
+package netlify
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/netlify/open-api/v2/go/plumbing/operations"
+)
+
+func dataSourceHooks() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists the outgoing hooks and notifications of a site.",
+		ReadContext: dataSourceHooksRead,
+		Schema: map[string]*schema.Schema{
+			"site_id": {
+				Description: "The ID of the site.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"hooks": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"event": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"data": {
+							Description: "The hook data. Marked sensitive as it may contain webhook URLs and tokens.",
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Sensitive:   true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"disabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"updated_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceHooksRead(ctx context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	meta := metaRaw.(*Meta)
+	params := operations.NewListHooksBySiteIDParamsWithContext(ctx)
+	params.SiteID = d.Get("site_id").(string)
+	resp, err := meta.Netlify.Operations.ListHooksBySiteID(params, meta.AuthInfo)
+	if err != nil {
+		return apiErrorDiags(err, cty.GetAttrPath("site_id"))
+	}
+
+	hooks := []interface{}{}
+	for _, hook := range resp.Payload {
+		// hook data may contain non-string values, which a map attribute
+		// cannot hold.
+		data := map[string]interface{}{}
+		if m, ok := hook.Data.(map[string]interface{}); ok {
+			for k, v := range m {
+				data[k] = fmt.Sprint(v)
+			}
+		}
+
+		hooks = append(hooks, map[string]interface{}{
+			"id":         hook.ID,
+			"type":       hook.Type,
+			"event":      hook.Event,
+			"data":       data,
+			"disabled":   hook.Disabled,
+			"created_at": hook.CreatedAt,
+			"updated_at": hook.UpdatedAt,
+		})
+	}
+
+	d.SetId(params.SiteID)
+	d.Set("hooks", hooks)
+
+	return nil
+}
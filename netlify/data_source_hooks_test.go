@@ -0,0 +1,42 @@
+package netlify
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDSHooks(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckSiteDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDSHooksConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.netlify_hooks.test", "hooks.#", "1"),
+					resource.TestCheckResourceAttrPair("data.netlify_hooks.test", "hooks.0.id", "netlify_hook.test", "id"),
+					resource.TestCheckResourceAttr("data.netlify_hooks.test", "hooks.0.data.url", "http://www.example.com"),
+				),
+			},
+		},
+	})
+}
+
+var testAccDSHooksConfig = `
+resource "netlify_site" "test" {}
+
+resource "netlify_hook" "test" {
+	site_id = "${netlify_site.test.id}"
+	type  = "url"
+	event = "deploy_locked"
+	data  = {
+		url = "http://www.example.com"
+	}
+}
+
+data "netlify_hooks" "test" {
+	site_id = netlify_hook.test.site_id
+}
+`
@@ -0,0 +1,144 @@
+package netlify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/netlify/open-api/v2/go/plumbing/operations"
+)
+
+func dataSourcePluginRuns() *schema.Resource {
+	return &schema.Resource{
+		Description: "Queries the latest run of each build plugin on a site.",
+		ReadContext: dataSourcePluginRunsRead,
+		Schema: map[string]*schema.Schema{
+			"site_id": {
+				Description: "The ID of the site.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"packages": {
+				Description: "If provided, only runs of these plugin packages are returned.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"state": {
+				Description: "If provided, only runs in this state are returned.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"fail_on_states": {
+				Description: "If any returned run is in one of these states, e.g. `failed_plugin`, reading the data source fails.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"runs": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"package": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"reporting_event": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"title": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"summary": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"text": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"deploy_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePluginRunsRead(ctx context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	meta := metaRaw.(*Meta)
+	params := operations.NewGetLatestPluginRunsParamsWithContext(ctx)
+	params.SiteID = d.Get("site_id").(string)
+	for _, p := range d.Get("packages").([]interface{}) {
+		params.Packages = append(params.Packages, p.(string))
+	}
+	if v, ok := d.GetOk("state"); ok {
+		state := v.(string)
+		params.State = &state
+	}
+
+	resp, err := meta.Netlify.Operations.GetLatestPluginRuns(params, meta.AuthInfo)
+	if err != nil {
+		return apiErrorDiags(err, cty.GetAttrPath("site_id"))
+	}
+
+	failStates := map[string]bool{}
+	for _, s := range d.Get("fail_on_states").(*schema.Set).List() {
+		failStates[s.(string)] = true
+	}
+
+	runs := []interface{}{}
+	var failed []string
+	for _, run := range resp.Payload {
+		runs = append(runs, map[string]interface{}{
+			"package":         run.Package,
+			"version":         run.Version,
+			"state":           run.State,
+			"reporting_event": run.ReportingEvent,
+			"title":           run.Title,
+			"summary":         run.Summary,
+			"text":            run.Text,
+			"deploy_id":       run.DeployID,
+		})
+
+		if failStates[run.State] {
+			failed = append(failed, fmt.Sprintf("%s@%s: %s (%s)", run.Package, run.Version, run.State, run.Summary))
+		}
+	}
+
+	if len(failed) > 0 {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Plugin runs in a failing state.",
+				Detail:   strings.Join(failed, "\n"),
+			},
+		}
+	}
+
+	d.SetId(params.SiteID)
+	d.Set("runs", runs)
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package netlify
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDSPluginRuns(t *testing.T) {
+	testAccSkipFake(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckSiteDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDSPluginRunsConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.netlify_plugin_runs.test", "runs.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+var testAccDSPluginRunsConfig = `
+resource "netlify_site" "test" {}
+
+data "netlify_plugin_runs" "test" {
+	site_id = netlify_site.test.id
+	fail_on_states = ["failed_plugin"]
+}
+`
@@ -0,0 +1,102 @@
+package netlify
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/netlify/open-api/v2/go/plumbing/operations"
+)
+
+func dataSourceServices() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists the add-on services available to sites.",
+		ReadContext: dataSourceServicesRead,
+		Schema: map[string]*schema.Schema{
+			"search": {
+				Description: "If provided, only services matching this search term are returned.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"services": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"slug": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"service_path": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"manifest_url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"environments": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"tags": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceServicesRead(ctx context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	meta := metaRaw.(*Meta)
+	params := operations.NewGetServicesParamsWithContext(ctx)
+	search := d.Get("search").(string)
+	if search != "" {
+		params.Search = &search
+	}
+
+	resp, err := meta.Netlify.Operations.GetServices(params, meta.AuthInfo)
+	if err != nil {
+		return apiErrorDiags(err, nil)
+	}
+
+	services := []interface{}{}
+	for _, service := range resp.Payload {
+		services = append(services, map[string]interface{}{
+			"id":           service.ID,
+			"slug":         service.Slug,
+			"name":         service.Name,
+			"description":  service.Description,
+			"service_path": service.ServicePath,
+			"manifest_url": service.ManifestURL,
+			"environments": service.Environments,
+			"tags":         service.Tags,
+		})
+	}
+
+	d.SetId("services/" + search)
+	d.Set("services", services)
+
+	return nil
+}
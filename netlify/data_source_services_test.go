@@ -0,0 +1,28 @@
+package netlify
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDSServices(t *testing.T) {
+	testAccSkipFake(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDSServicesConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.netlify_services.test", "services.#"),
+				),
+			},
+		},
+	})
+}
+
+var testAccDSServicesConfig = `
+data "netlify_services" "test" {}
+`
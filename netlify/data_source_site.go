@@ -92,40 +92,28 @@ func dataSourceSiteRead(ctx context.Context, d *schema.ResourceData, metaRaw int
 	var site *models.Site
 	// if using ID, it's easy
 	if id, ok := d.GetOk("site_id"); ok {
-		params := operations.NewGetSiteParams()
+		params := operations.NewGetSiteParamsWithContext(ctx)
 		params.SiteID = id.(string)
 		resp, err := meta.Netlify.Operations.GetSite(params, meta.AuthInfo)
 		if err != nil {
 			// If it is a 404 it was removed remotely
-			if v, ok := err.(*operations.GetSiteDefault); ok && v.Code() == 404 {
+			if isNotFound(err) {
 				d.SetId("")
 				return nil
 			}
-			return diag.FromErr(err)
+			return apiErrorDiags(err, nil)
 		}
 		site = resp.Payload
 		bs, _ := json.Marshal(site)
 		fmt.Print(string(bs))
 		// otherwise, query all sites and look for ones that match
 	} else {
-		params := operations.NewListSitesParams()
-		name := d.Get("name").(string)
-		params.Name = &name
-		resp, err := meta.Netlify.Operations.ListSites(params, meta.AuthInfo)
+		var err error
+		site, err = findSiteByName(ctx, meta, d.Get("name").(string))
 		if err != nil {
-			return diag.FromErr(err)
+			return apiErrorDiags(err, nil)
 		}
-		// name match should be first one. if it's not, then name is not specific enough.
-		sites := resp.Payload
-		has_match := false
-		for _, siteI := range sites {
-			if siteI.Name == name {
-				site = siteI
-				has_match = true
-				break
-			}
-		}
-		if !has_match {
+		if site == nil {
 			d.SetId("")
 			return nil
 		}
@@ -0,0 +1,147 @@
+package netlify
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-openapi/runtime"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/netlify/open-api/v2/go/models"
+)
+
+// The header Netlify identifies requests with.
+const requestIDHeader = "X-Nf-Request-Id"
+
+// apiError is an error response of the Netlify API. It wraps the error of the
+// generated client, so it can still be inspected with errors.As.
+type apiError struct {
+	err       error
+	operation string
+	requestID string
+}
+
+func (e *apiError) Error() string {
+	return e.err.Error()
+}
+
+func (e *apiError) Unwrap() error {
+	return e.err
+}
+
+// defaultResponse is implemented by the default responses of all operations,
+// which the generated client returns as errors.
+type defaultResponse interface {
+	error
+	Code() int
+	GetPayload() *models.Error
+}
+
+// apiErrorTransport is a runtime.ClientTransport that wraps the error
+// responses of all operations in an apiError.
+type apiErrorTransport struct {
+	next runtime.ClientTransport
+}
+
+func (t *apiErrorTransport) Submit(op *runtime.ClientOperation) (interface{}, error) {
+	op.Reader = &apiErrorReader{next: op.Reader, operation: op.ID}
+	return t.next.Submit(op)
+}
+
+type apiErrorReader struct {
+	next      runtime.ClientResponseReader
+	operation string
+}
+
+func (r *apiErrorReader) ReadResponse(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+	result, err := r.next.ReadResponse(response, consumer)
+	if err != nil && response.Code() >= 400 {
+		err = &apiError{
+			err:       err,
+			operation: r.operation,
+			requestID: response.GetHeader(requestIDHeader),
+		}
+	}
+	return result, err
+}
+
+// Returns the HTTP status of an API error, or 0 if err is not one.
+func apiErrorStatus(err error) int {
+	var d defaultResponse
+	if errors.As(err, &d) {
+		return d.Code()
+	}
+
+	var a *runtime.APIError
+	if errors.As(err, &a) {
+		return a.Code
+	}
+
+	return 0
+}
+
+// Returns whether err is an API error for a missing object.
+func isNotFound(err error) bool {
+	return apiErrorStatus(err) == http.StatusNotFound
+}
+
+// Translates an error into diagnostics. API errors are reported with their
+// status, message and request ID. Client errors are attributed to path, if
+// given, as the attribute identifying the object that was not found or could
+// not be changed. Other errors are reported as is.
+func apiErrorDiags(err error, path cty.Path) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+
+	summary, detail, status := apiErrorMessage(err)
+	if status == 0 {
+		return diag.FromErr(err)
+	}
+
+	result := diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  summary,
+		Detail:   detail,
+	}
+	if isClientError(status) {
+		result.AttributePath = path
+	}
+	return diag.Diagnostics{result}
+}
+
+// Returns the summary and detail of an API error, and its status, which is 0
+// if err is not an API error.
+func apiErrorMessage(err error) (string, string, int) {
+	status := apiErrorStatus(err)
+	if status == 0 {
+		return err.Error(), "", 0
+	}
+
+	message := http.StatusText(status)
+	var d defaultResponse
+	if errors.As(err, &d) && d.GetPayload() != nil && d.GetPayload().Message != "" {
+		message = d.GetPayload().Message
+	}
+
+	details := []string{fmt.Sprintf("Status: %d %s", status, http.StatusText(status))}
+	var a *apiError
+	if errors.As(err, &a) {
+		if a.operation != "" {
+			details = append(details, "Operation: "+a.operation)
+		}
+		if a.requestID != "" {
+			details = append(details, "Request ID: "+a.requestID)
+		}
+	}
+
+	return "Netlify API error: " + message, strings.Join(details, "\n"), status
+}
+
+// Returns whether a status is caused by the request rather than the server
+// or the rate limit.
+func isClientError(status int) bool {
+	return status >= 400 && status < 500 && status != http.StatusTooManyRequests
+}
@@ -0,0 +1,69 @@
+package netlify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/netlify/open-api/v2/go/plumbing/operations"
+)
+
+func TestAPIErrorDiags(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(requestIDHeader, "01REQUEST")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"code": 404, "message": "Not Found"}`))
+	}))
+	defer server.Close()
+
+	config := Config{Token: "token", BaseURL: server.URL, MaxRetries: 0}
+	metaRaw, err := config.Client(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := metaRaw.(*Meta)
+
+	params := operations.NewGetSiteParamsWithContext(context.Background())
+	params.SiteID = "missing"
+	_, err = meta.Netlify.Operations.GetSite(params, meta.AuthInfo)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if !isNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+	var v *operations.GetSiteDefault
+	if !errors.As(err, &v) {
+		t.Fatalf("expected the default response to be unwrappable, got %T", err)
+	}
+
+	diags := apiErrorDiags(err, cty.GetAttrPath("site_id"))
+	if len(diags) != 1 {
+		t.Fatalf("expected one diagnostic, got %d", len(diags))
+	}
+	d := diags[0]
+	if d.Summary != "Netlify API error: Not Found" {
+		t.Errorf("unexpected summary %q", d.Summary)
+	}
+	for _, s := range []string{"Status: 404 Not Found", "Operation: getSite", "Request ID: 01REQUEST"} {
+		if !strings.Contains(d.Detail, s) {
+			t.Errorf("expected detail %q to contain %q", d.Detail, s)
+		}
+	}
+	if !d.AttributePath.Equals(cty.GetAttrPath("site_id")) {
+		t.Errorf("unexpected attribute path %#v", d.AttributePath)
+	}
+}
+
+func TestAPIErrorDiags_other(t *testing.T) {
+	diags := apiErrorDiags(errors.New("boom"), cty.GetAttrPath("site_id"))
+	if len(diags) != 1 || diags[0].Summary != "boom" || diags[0].AttributePath != nil {
+		t.Fatalf("unexpected diagnostics %#v", diags)
+	}
+}
@@ -0,0 +1,1141 @@
+package netlify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/netlify/open-api/v2/go/models"
+	"github.com/netlify/open-api/v2/go/plumbing/operations"
+)
+
+// The token the fake API accepts.
+const fakeAPIToken = "fake-token"
+
+// The path the fake API is served under, like the real one.
+const fakeAPIBasePath = "/api/v1"
+
+// fakeAPI is an in-process fake of the parts of the Netlify API used by the
+// acceptance tests: the current user and accounts, sites with their deploys
+// and forms, hooks, build hooks, environment variables, deploy keys and DNS.
+// It keeps its objects in memory, so resources can be created, read, changed
+// and deleted as with the real API, and can inject faults into requests.
+type fakeAPI struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	nextID int
+	faults []*fakeFault
+
+	accounts   []*models.AccountMembership
+	sites      map[string]*models.Site
+	hooks      map[string]*models.Hook
+	buildHooks map[string]*models.BuildHook
+	deployKeys map[string]*models.DeployKey
+	dnsZones   map[string]*models.DNSZone
+	dnsRecords map[string]*models.DNSRecord
+	// Environment variables by account, site ID (empty for account level
+	// variables) and key.
+	envVars map[string]*models.EnvVar
+	// How often each build hook was triggered.
+	buildHookTriggers map[string]int
+}
+
+// A fault makes the fake API respond with an error status to the next
+// requests matching the method and path prefix.
+type fakeFault struct {
+	method string
+	path   string
+	status int
+	count  int
+}
+
+type fakeRoute struct {
+	method  string
+	pattern string
+	handler func(w http.ResponseWriter, r *http.Request, args []string)
+}
+
+// Starts a fake API, which must be closed when done.
+func newFakeAPI() *fakeAPI {
+	f := &fakeAPI{
+		accounts: []*models.AccountMembership{
+			{ID: "fake-personal-account", Name: "Fake User", Slug: "fake-user", Type: "personal", TypeName: "Starter"},
+			{ID: "fake-team-account", Name: "Fake Team", Slug: "fake-team", Type: "team", TypeName: "Pro"},
+		},
+		sites:             map[string]*models.Site{},
+		hooks:             map[string]*models.Hook{},
+		buildHooks:        map[string]*models.BuildHook{},
+		deployKeys:        map[string]*models.DeployKey{},
+		dnsZones:          map[string]*models.DNSZone{},
+		dnsRecords:        map[string]*models.DNSRecord{},
+		envVars:           map[string]*models.EnvVar{},
+		buildHookTriggers: map[string]int{},
+	}
+	f.Server = httptest.NewServer(f)
+	return f
+}
+
+// Returns the base URL to configure the provider with.
+func (f *fakeAPI) BaseURL() string {
+	return f.URL + fakeAPIBasePath
+}
+
+// Makes the next count requests with the method and a path starting with
+// path, relative to the base URL, fail with the status.
+func (f *fakeAPI) injectFault(method, path string, status, count int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.faults = append(f.faults, &fakeFault{method: method, path: path, status: status, count: count})
+}
+
+// Returns how many injected faults have not been returned yet.
+func (f *fakeAPI) pendingFaults() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	n := 0
+	for _, fault := range f.faults {
+		n += fault.count
+	}
+	return n
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// Build hooks are called without a token, outside of the API.
+	if r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/build_hooks/") {
+		id := strings.TrimPrefix(r.URL.Path, "/build_hooks/")
+		if _, ok := f.buildHooks[id]; !ok {
+			fakeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+		f.buildHookTriggers[id]++
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if !strings.HasPrefix(r.URL.Path, fakeAPIBasePath+"/") {
+		fakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	path := strings.TrimPrefix(r.URL.Path, fakeAPIBasePath)
+
+	if r.Header.Get("Authorization") != "Bearer "+fakeAPIToken {
+		fakeError(w, http.StatusUnauthorized, "Access Denied")
+		return
+	}
+
+	for _, fault := range f.faults {
+		if fault.count > 0 && fault.method == r.Method && strings.HasPrefix(path, fault.path) {
+			fault.count--
+			if fault.status == http.StatusTooManyRequests {
+				w.Header().Set("Retry-After", "0")
+			}
+			fakeError(w, fault.status, http.StatusText(fault.status))
+			return
+		}
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for _, route := range f.routes() {
+		if route.method != r.Method {
+			continue
+		}
+		if args, ok := matchFakeRoute(route.pattern, segments); ok {
+			route.handler(w, r, args)
+			return
+		}
+	}
+
+	fakeError(w, http.StatusNotFound, "Not Found")
+}
+
+func (f *fakeAPI) routes() []fakeRoute {
+	return []fakeRoute{
+		{"GET", "user", f.getCurrentUser},
+		{"GET", "accounts", f.listAccounts},
+		{"GET", "accounts/*", f.getAccount},
+
+		{"GET", "sites", f.listSites},
+		{"POST", "sites", f.createSite},
+		{"POST", "*/sites", f.createSite},
+		{"GET", "sites/*", f.getSite},
+		{"PATCH", "sites/*", f.updateSite},
+		{"DELETE", "sites/*", f.deleteSite},
+		{"GET", "sites/*/deploys", f.listSiteDeploys},
+		{"GET", "sites/*/forms", f.listSiteForms},
+
+		{"GET", "hooks/types", f.listHookTypes},
+		{"GET", "hooks", f.listHooks},
+		{"POST", "hooks", f.createHook},
+		{"GET", "hooks/*", f.getHook},
+		{"PUT", "hooks/*", f.updateHook},
+		{"DELETE", "hooks/*", f.deleteHook},
+		{"POST", "hooks/*/enable", f.enableHook},
+
+		{"GET", "sites/*/build_hooks", f.listBuildHooks},
+		{"POST", "sites/*/build_hooks", f.createBuildHook},
+		{"GET", "sites/*/build_hooks/*", f.getBuildHook},
+		{"PUT", "sites/*/build_hooks/*", f.updateBuildHook},
+		{"DELETE", "sites/*/build_hooks/*", f.deleteBuildHook},
+
+		{"GET", "accounts/*/env", f.listEnvVars},
+		{"POST", "accounts/*/env", f.createEnvVars},
+		{"GET", "accounts/*/env/*", f.getEnvVar},
+		{"PUT", "accounts/*/env/*", f.updateEnvVar},
+		{"PATCH", "accounts/*/env/*", f.setEnvVarValue},
+		{"DELETE", "accounts/*/env/*", f.deleteEnvVar},
+
+		{"GET", "deploy_keys", f.listDeployKeys},
+		{"POST", "deploy_keys", f.createDeployKey},
+		{"GET", "deploy_keys/*", f.getDeployKey},
+		{"DELETE", "deploy_keys/*", f.deleteDeployKey},
+
+		{"GET", "dns_zones", f.listDNSZones},
+		{"POST", "dns_zones", f.createDNSZone},
+		{"GET", "dns_zones/*", f.getDNSZone},
+		{"DELETE", "dns_zones/*", f.deleteDNSZone},
+		{"GET", "dns_zones/*/dns_records", f.listDNSRecords},
+		{"POST", "dns_zones/*/dns_records", f.createDNSRecord},
+		{"GET", "dns_zones/*/dns_records/*", f.getDNSRecord},
+		{"DELETE", "dns_zones/*/dns_records/*", f.deleteDNSRecord},
+	}
+}
+
+// Matches path segments against a pattern, in which * matches any segment,
+// returning the matched segments.
+func matchFakeRoute(pattern string, segments []string) ([]string, bool) {
+	parts := strings.Split(pattern, "/")
+	if len(parts) != len(segments) {
+		return nil, false
+	}
+
+	var args []string
+	for i, p := range parts {
+		switch {
+		case p == "*":
+			args = append(args, segments[i])
+		case p != segments[i]:
+			return nil, false
+		}
+	}
+	return args, true
+}
+
+func fakeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set(requestIDHeader, fmt.Sprintf("fake-%d", time.Now().UnixNano()))
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// Returns the page of items the request asks for. Like the API, pages hold
+// 100 items unless per_page is given.
+func fakePage[T any](r *http.Request, items []T) []T {
+	page, perPage := 1, 100
+	if v, err := strconv.Atoi(r.URL.Query().Get("page")); err == nil && v > 0 {
+		page = v
+	}
+	if v, err := strconv.Atoi(r.URL.Query().Get("per_page")); err == nil && v > 0 {
+		perPage = v
+	}
+
+	start := (page - 1) * perPage
+	if start >= len(items) {
+		return items[:0]
+	}
+	end := start + perPage
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end]
+}
+
+func fakeError(w http.ResponseWriter, status int, message string) {
+	fakeJSON(w, status, &models.Error{Code: int64(status), Message: message})
+}
+
+// Decodes a request body, if any.
+func fakeDecode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil && err != io.EOF {
+		fakeError(w, http.StatusBadRequest, fmt.Sprintf("Invalid request body: %s", err))
+		return false
+	}
+	return true
+}
+
+func (f *fakeAPI) newID() string {
+	f.nextID++
+	return fmt.Sprintf("%024x", f.nextID)
+}
+
+func fakeNow() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+func (f *fakeAPI) getCurrentUser(w http.ResponseWriter, r *http.Request, args []string) {
+	fakeJSON(w, http.StatusOK, &models.User{
+		ID:             "fake-user-id",
+		Email:          "fake@example.com",
+		FullName:       "Fake User",
+		LoginProviders: []string{"email"},
+	})
+}
+
+func (f *fakeAPI) listAccounts(w http.ResponseWriter, r *http.Request, args []string) {
+	fakeJSON(w, http.StatusOK, f.accounts)
+}
+
+func (f *fakeAPI) getAccount(w http.ResponseWriter, r *http.Request, args []string) {
+	account := f.account(args[0])
+	if account == nil {
+		fakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	fakeJSON(w, http.StatusOK, []*models.AccountMembership{account})
+}
+
+// Returns the account with the ID or slug, if any.
+func (f *fakeAPI) account(idOrSlug string) *models.AccountMembership {
+	for _, a := range f.accounts {
+		if a.ID == idOrSlug || a.Slug == idOrSlug {
+			return a
+		}
+	}
+	return nil
+}
+
+// The fields of a site that can be created or updated. Unlike in
+// models.SiteSetup, the build settings are kept as sent, so that a setting
+// that is not sent can be told apart from one sent as null, which clears it
+// like the API does.
+type fakeSiteSetup struct {
+	Name          string                     `json:"name"`
+	CustomDomain  string                     `json:"custom_domain"`
+	Repo          *models.RepoInfo           `json:"repo"`
+	BuildSettings map[string]json.RawMessage `json:"build_settings"`
+}
+
+func (f *fakeAPI) listSites(w http.ResponseWriter, r *http.Request, args []string) {
+	name := r.URL.Query().Get("name")
+	sites := []*models.Site{}
+	for _, s := range f.sites {
+		if strings.Contains(s.Name, name) {
+			sites = append(sites, s)
+		}
+	}
+	sort.Slice(sites, func(i, j int) bool { return sites[i].ID < sites[j].ID })
+	fakeJSON(w, http.StatusOK, fakePage(r, sites))
+}
+
+func (f *fakeAPI) createSite(w http.ResponseWriter, r *http.Request, args []string) {
+	account := f.accounts[0]
+	if len(args) > 0 {
+		if account = f.account(args[0]); account == nil {
+			fakeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+	}
+
+	var setup fakeSiteSetup
+	if !fakeDecode(w, r, &setup) {
+		return
+	}
+
+	id := f.newID()
+	site := &models.Site{
+		ID:          id,
+		Name:        "fake-site-" + id[len(id)-6:],
+		AccountSlug: account.Slug,
+		AccountName: account.Name,
+		CreatedAt:   fakeNow(),
+	}
+	if !f.applySiteSetup(w, site, &setup) {
+		return
+	}
+
+	f.sites[id] = site
+	fakeJSON(w, http.StatusCreated, site)
+}
+
+func (f *fakeAPI) getSite(w http.ResponseWriter, r *http.Request, args []string) {
+	site, ok := f.sites[args[0]]
+	if !ok {
+		fakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	fakeJSON(w, http.StatusOK, site)
+}
+
+func (f *fakeAPI) updateSite(w http.ResponseWriter, r *http.Request, args []string) {
+	site, ok := f.sites[args[0]]
+	if !ok {
+		fakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	var setup fakeSiteSetup
+	if !fakeDecode(w, r, &setup) {
+		return
+	}
+
+	// Validate a copy, so a failed update leaves the site unchanged.
+	updated := *site
+	if site.BuildSettings != nil {
+		settings := *site.BuildSettings
+		updated.BuildSettings = &settings
+	}
+	if !f.applySiteSetup(w, &updated, &setup) {
+		return
+	}
+
+	*site = updated
+	fakeJSON(w, http.StatusOK, site)
+}
+
+// Applies the fields set in a create or update request to a site. Linking a
+// repository publishes a deploy of it.
+func (f *fakeAPI) applySiteSetup(w http.ResponseWriter, site *models.Site, setup *fakeSiteSetup) bool {
+	if setup.Name != "" && setup.Name != site.Name {
+		for _, s := range f.sites {
+			if s.Name == setup.Name {
+				fakeError(w, http.StatusUnprocessableEntity, "name: must be unique")
+				return false
+			}
+		}
+		site.Name = setup.Name
+	}
+	if setup.CustomDomain != "" {
+		site.CustomDomain = setup.CustomDomain
+	}
+
+	site.URL = fmt.Sprintf("https://%s.netlify.app", site.Name)
+	site.DeployURL = fmt.Sprintf("https://main--%s.netlify.app", site.Name)
+	site.UpdatedAt = fakeNow()
+
+	if setup.Repo != nil {
+		if site.BuildSettings == nil {
+			site.BuildSettings = &models.RepoInfo{}
+		}
+		settings := site.BuildSettings
+		settings.Provider = setup.Repo.Provider
+		settings.RepoPath = setup.Repo.RepoPath
+		settings.RepoBranch = setup.Repo.RepoBranch
+		settings.Cmd = setup.Repo.Cmd
+		settings.Dir = setup.Repo.Dir
+		if setup.Repo.DeployKeyID != "" {
+			settings.DeployKeyID = setup.Repo.DeployKeyID
+		}
+
+		if site.PublishedDeploy == nil {
+			site.PublishedDeploy = &models.Deploy{
+				ID:          f.newID(),
+				SiteID:      site.ID,
+				Branch:      settings.RepoBranch,
+				Context:     "production",
+				State:       "ready",
+				CreatedAt:   fakeNow(),
+				PublishedAt: fakeNow(),
+			}
+		}
+	}
+
+	if setup.BuildSettings != nil {
+		if site.BuildSettings == nil {
+			site.BuildSettings = &models.RepoInfo{}
+		}
+		if v, ok := setup.BuildSettings["allowed_branches"]; ok {
+			site.BuildSettings.AllowedBranches = nil
+			json.Unmarshal(v, &site.BuildSettings.AllowedBranches)
+		}
+		if v, ok := setup.BuildSettings["deploy_key_id"]; ok {
+			site.BuildSettings.DeployKeyID = ""
+			json.Unmarshal(v, &site.BuildSettings.DeployKeyID)
+		}
+	}
+
+	return true
+}
+
+// Deleting a site deletes the objects that belong to it.
+func (f *fakeAPI) deleteSite(w http.ResponseWriter, r *http.Request, args []string) {
+	id := args[0]
+	if _, ok := f.sites[id]; !ok {
+		fakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	delete(f.sites, id)
+	for k, h := range f.hooks {
+		if h.SiteID == id {
+			delete(f.hooks, k)
+		}
+	}
+	for k, h := range f.buildHooks {
+		if h.SiteID == id {
+			delete(f.buildHooks, k)
+		}
+	}
+	for k := range f.envVars {
+		if strings.Split(k, "/")[1] == id {
+			delete(f.envVars, k)
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeAPI) listSiteDeploys(w http.ResponseWriter, r *http.Request, args []string) {
+	site, ok := f.sites[args[0]]
+	if !ok {
+		fakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	deploys := []*models.Deploy{}
+	if site.PublishedDeploy != nil {
+		deploys = append(deploys, site.PublishedDeploy)
+	}
+	fakeJSON(w, http.StatusOK, deploys)
+}
+
+// Sites have no forms, as they are created by deploys.
+func (f *fakeAPI) listSiteForms(w http.ResponseWriter, r *http.Request, args []string) {
+	if _, ok := f.sites[args[0]]; !ok {
+		fakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	fakeJSON(w, http.StatusOK, []*models.Form{})
+}
+
+func (f *fakeAPI) listHookTypes(w http.ResponseWriter, r *http.Request, args []string) {
+	events := []string{"deploy_building", "deploy_created", "deploy_failed", "deploy_locked", "deploy_unlocked"}
+	fakeJSON(w, http.StatusOK, []*models.HookType{
+		{Name: "email", Events: events, Fields: []interface{}{map[string]interface{}{"name": "email", "required": true}}},
+		{Name: "github_commit_status", Events: events, Fields: []interface{}{map[string]interface{}{"name": "access_token", "required": true}}},
+		{Name: "slack", Events: events, Fields: []interface{}{map[string]interface{}{"name": "url", "required": true}}},
+		{Name: "url", Events: events, Fields: []interface{}{map[string]interface{}{"name": "url", "required": true}, "signature_secret"}},
+	})
+}
+
+func (f *fakeAPI) listHooks(w http.ResponseWriter, r *http.Request, args []string) {
+	siteID := r.URL.Query().Get("site_id")
+	if _, ok := f.sites[siteID]; !ok {
+		fakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	hooks := []*models.Hook{}
+	for _, h := range f.hooks {
+		if h.SiteID == siteID {
+			hooks = append(hooks, h)
+		}
+	}
+	sort.Slice(hooks, func(i, j int) bool { return hooks[i].ID < hooks[j].ID })
+	fakeJSON(w, http.StatusOK, hooks)
+}
+
+func (f *fakeAPI) createHook(w http.ResponseWriter, r *http.Request, args []string) {
+	siteID := r.URL.Query().Get("site_id")
+	if _, ok := f.sites[siteID]; !ok {
+		fakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	var hook models.Hook
+	if !fakeDecode(w, r, &hook) {
+		return
+	}
+
+	hook.ID = f.newID()
+	hook.SiteID = siteID
+	hook.CreatedAt = fakeNow()
+	hook.UpdatedAt = hook.CreatedAt
+	f.hooks[hook.ID] = &hook
+	fakeJSON(w, http.StatusCreated, &hook)
+}
+
+func (f *fakeAPI) getHook(w http.ResponseWriter, r *http.Request, args []string) {
+	hook, ok := f.hooks[args[0]]
+	if !ok {
+		fakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	fakeJSON(w, http.StatusOK, hook)
+}
+
+// Like the real API, updating a hook can disable it but not re-enable it.
+func (f *fakeAPI) updateHook(w http.ResponseWriter, r *http.Request, args []string) {
+	hook, ok := f.hooks[args[0]]
+	if !ok {
+		fakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	var update models.Hook
+	if !fakeDecode(w, r, &update) {
+		return
+	}
+
+	hook.Type = update.Type
+	hook.Event = update.Event
+	hook.Data = update.Data
+	hook.Disabled = hook.Disabled || update.Disabled
+	hook.UpdatedAt = fakeNow()
+	fakeJSON(w, http.StatusOK, hook)
+}
+
+func (f *fakeAPI) deleteHook(w http.ResponseWriter, r *http.Request, args []string) {
+	if _, ok := f.hooks[args[0]]; !ok {
+		fakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	delete(f.hooks, args[0])
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeAPI) enableHook(w http.ResponseWriter, r *http.Request, args []string) {
+	hook, ok := f.hooks[args[0]]
+	if !ok {
+		fakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	hook.Disabled = false
+	fakeJSON(w, http.StatusOK, hook)
+}
+
+func (f *fakeAPI) listBuildHooks(w http.ResponseWriter, r *http.Request, args []string) {
+	if _, ok := f.sites[args[0]]; !ok {
+		fakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	hooks := []*models.BuildHook{}
+	for _, h := range f.buildHooks {
+		if h.SiteID == args[0] {
+			hooks = append(hooks, h)
+		}
+	}
+	sort.Slice(hooks, func(i, j int) bool { return hooks[i].ID < hooks[j].ID })
+	fakeJSON(w, http.StatusOK, hooks)
+}
+
+func (f *fakeAPI) createBuildHook(w http.ResponseWriter, r *http.Request, args []string) {
+	if _, ok := f.sites[args[0]]; !ok {
+		fakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	var setup models.BuildHookSetup
+	if !fakeDecode(w, r, &setup) {
+		return
+	}
+
+	id := f.newID()
+	hook := &models.BuildHook{
+		ID:        id,
+		SiteID:    args[0],
+		Title:     setup.Title,
+		Branch:    setup.Branch,
+		URL:       f.URL + "/build_hooks/" + id,
+		CreatedAt: fakeNow(),
+	}
+	f.buildHooks[id] = hook
+	fakeJSON(w, http.StatusCreated, hook)
+}
+
+// Returns the build hook of a site, if any.
+func (f *fakeAPI) buildHook(siteID, id string) *models.BuildHook {
+	hook, ok := f.buildHooks[id]
+	if !ok || hook.SiteID != siteID {
+		return nil
+	}
+	return hook
+}
+
+func (f *fakeAPI) getBuildHook(w http.ResponseWriter, r *http.Request, args []string) {
+	hook := f.buildHook(args[0], args[1])
+	if hook == nil {
+		fakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	fakeJSON(w, http.StatusOK, hook)
+}
+
+func (f *fakeAPI) updateBuildHook(w http.ResponseWriter, r *http.Request, args []string) {
+	hook := f.buildHook(args[0], args[1])
+	if hook == nil {
+		fakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	var setup models.BuildHookSetup
+	if !fakeDecode(w, r, &setup) {
+		return
+	}
+
+	hook.Title = setup.Title
+	hook.Branch = setup.Branch
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeAPI) deleteBuildHook(w http.ResponseWriter, r *http.Request, args []string) {
+	if f.buildHook(args[0], args[1]) == nil {
+		fakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	delete(f.buildHooks, args[1])
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Returns the key of the environment variables of an account and optionally
+// a site, or false if either does not exist.
+func (f *fakeAPI) envVarScope(accountID, siteID string) (string, bool) {
+	account := f.account(accountID)
+	if account == nil {
+		return "", false
+	}
+	if siteID != "" {
+		site, ok := f.sites[siteID]
+		if !ok || site.AccountSlug != account.Slug {
+			return "", false
+		}
+	}
+	return account.Slug + "/" + siteID + "/", true
+}
+
+func (f *fakeAPI) listEnvVars(w http.ResponseWriter, r *http.Request, args []string) {
+	scope, ok := f.envVarScope(args[0], r.URL.Query().Get("site_id"))
+	if !ok {
+		fakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	envVars := []*models.EnvVar{}
+	for k, v := range f.envVars {
+		if strings.HasPrefix(k, scope) {
+			envVars = append(envVars, v)
+		}
+	}
+	sort.Slice(envVars, func(i, j int) bool { return envVars[i].Key < envVars[j].Key })
+	fakeJSON(w, http.StatusOK, envVars)
+}
+
+func (f *fakeAPI) createEnvVars(w http.ResponseWriter, r *http.Request, args []string) {
+	scope, ok := f.envVarScope(args[0], r.URL.Query().Get("site_id"))
+	if !ok {
+		fakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	var items []*models.CreateEnvVarsParamsBodyItems
+	if !fakeDecode(w, r, &items) {
+		return
+	}
+
+	created := []*models.EnvVar{}
+	for _, item := range items {
+		if _, ok := f.envVars[scope+item.Key]; ok {
+			fakeError(w, http.StatusConflict, fmt.Sprintf("Environment variable %s already exists", item.Key))
+			return
+		}
+		envVar := &models.EnvVar{
+			Key:    item.Key,
+			Scopes: item.Scopes,
+			Values: f.envVarValues(item.Values),
+		}
+		f.envVars[scope+item.Key] = envVar
+		created = append(created, envVar)
+	}
+	fakeJSON(w, http.StatusCreated, created)
+}
+
+// Assigns IDs to new environment variable values.
+func (f *fakeAPI) envVarValues(values []*models.EnvVarValue) []*models.EnvVarValue {
+	for _, v := range values {
+		if v.ID == "" {
+			v.ID = f.newID()
+		}
+	}
+	return values
+}
+
+func (f *fakeAPI) getEnvVar(w http.ResponseWriter, r *http.Request, args []string) {
+	scope, ok := f.envVarScope(args[0], r.URL.Query().Get("site_id"))
+	envVar := f.envVars[scope+args[1]]
+	if !ok || envVar == nil {
+		fakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	fakeJSON(w, http.StatusOK, envVar)
+}
+
+func (f *fakeAPI) updateEnvVar(w http.ResponseWriter, r *http.Request, args []string) {
+	scope, ok := f.envVarScope(args[0], r.URL.Query().Get("site_id"))
+	envVar := f.envVars[scope+args[1]]
+	if !ok || envVar == nil {
+		fakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	var update models.UpdateEnvVarParamsBody
+	if !fakeDecode(w, r, &update) {
+		return
+	}
+
+	if update.Key != args[1] {
+		if _, ok := f.envVars[scope+update.Key]; ok {
+			fakeError(w, http.StatusConflict, fmt.Sprintf("Environment variable %s already exists", update.Key))
+			return
+		}
+		delete(f.envVars, scope+args[1])
+		f.envVars[scope+update.Key] = envVar
+	}
+
+	envVar.Key = update.Key
+	envVar.Scopes = update.Scopes
+	envVar.Values = f.envVarValues(update.Values)
+	fakeJSON(w, http.StatusOK, envVar)
+}
+
+func (f *fakeAPI) setEnvVarValue(w http.ResponseWriter, r *http.Request, args []string) {
+	scope, ok := f.envVarScope(args[0], r.URL.Query().Get("site_id"))
+	envVar := f.envVars[scope+args[1]]
+	if !ok || envVar == nil {
+		fakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	var body models.SetEnvVarValueParamsBody
+	if !fakeDecode(w, r, &body) {
+		return
+	}
+
+	found := false
+	for _, v := range envVar.Values {
+		if v.Context == body.Context {
+			v.Value = body.Value
+			found = true
+		}
+	}
+	if !found {
+		envVar.Values = append(envVar.Values, &models.EnvVarValue{
+			ID:      f.newID(),
+			Context: body.Context,
+			Value:   body.Value,
+		})
+	}
+	fakeJSON(w, http.StatusCreated, envVar)
+}
+
+func (f *fakeAPI) deleteEnvVar(w http.ResponseWriter, r *http.Request, args []string) {
+	scope, ok := f.envVarScope(args[0], r.URL.Query().Get("site_id"))
+	if _, exists := f.envVars[scope+args[1]]; !ok || !exists {
+		fakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	delete(f.envVars, scope+args[1])
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeAPI) listDeployKeys(w http.ResponseWriter, r *http.Request, args []string) {
+	keys := []*models.DeployKey{}
+	for _, k := range f.deployKeys {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].ID < keys[j].ID })
+	fakeJSON(w, http.StatusOK, keys)
+}
+
+func (f *fakeAPI) createDeployKey(w http.ResponseWriter, r *http.Request, args []string) {
+	id := f.newID()
+	key := &models.DeployKey{
+		ID:        id,
+		PublicKey: "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQ" + id + " fake",
+		CreatedAt: fakeNow(),
+	}
+	f.deployKeys[id] = key
+	fakeJSON(w, http.StatusCreated, key)
+}
+
+func (f *fakeAPI) getDeployKey(w http.ResponseWriter, r *http.Request, args []string) {
+	key, ok := f.deployKeys[args[0]]
+	if !ok {
+		fakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	fakeJSON(w, http.StatusOK, key)
+}
+
+func (f *fakeAPI) deleteDeployKey(w http.ResponseWriter, r *http.Request, args []string) {
+	if _, ok := f.deployKeys[args[0]]; !ok {
+		fakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	delete(f.deployKeys, args[0])
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeAPI) listDNSZones(w http.ResponseWriter, r *http.Request, args []string) {
+	account := r.URL.Query().Get("account_slug")
+	zones := []*models.DNSZone{}
+	for _, z := range f.dnsZones {
+		if account == "" || z.AccountSlug == account {
+			zones = append(zones, z)
+		}
+	}
+	sort.Slice(zones, func(i, j int) bool { return zones[i].ID < zones[j].ID })
+	fakeJSON(w, http.StatusOK, zones)
+}
+
+func (f *fakeAPI) createDNSZone(w http.ResponseWriter, r *http.Request, args []string) {
+	var setup models.DNSZoneSetup
+	if !fakeDecode(w, r, &setup) {
+		return
+	}
+
+	for _, z := range f.dnsZones {
+		if z.Name == setup.Name {
+			fakeError(w, http.StatusUnprocessableEntity, "name: has already been taken")
+			return
+		}
+	}
+
+	account := f.accounts[0]
+	if setup.AccountSlug != "" {
+		if account = f.account(setup.AccountSlug); account == nil {
+			fakeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+	}
+	if setup.SiteID != "" {
+		if _, ok := f.sites[setup.SiteID]; !ok {
+			fakeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+	}
+
+	zone := &models.DNSZone{
+		ID:          f.newID(),
+		Name:        setup.Name,
+		Domain:      setup.Name,
+		SiteID:      setup.SiteID,
+		AccountID:   account.ID,
+		AccountSlug: account.Slug,
+		AccountName: account.Name,
+		DNSServers:  []string{"dns1.p01.nsone.net", "dns2.p01.nsone.net"},
+		CreatedAt:   fakeNow(),
+	}
+	f.dnsZones[zone.ID] = zone
+	fakeJSON(w, http.StatusCreated, zone)
+}
+
+func (f *fakeAPI) getDNSZone(w http.ResponseWriter, r *http.Request, args []string) {
+	zone, ok := f.dnsZones[args[0]]
+	if !ok {
+		fakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	fakeJSON(w, http.StatusOK, zone)
+}
+
+func (f *fakeAPI) deleteDNSZone(w http.ResponseWriter, r *http.Request, args []string) {
+	if _, ok := f.dnsZones[args[0]]; !ok {
+		fakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	delete(f.dnsZones, args[0])
+	for k, rec := range f.dnsRecords {
+		if rec.DNSZoneID == args[0] {
+			delete(f.dnsRecords, k)
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeAPI) listDNSRecords(w http.ResponseWriter, r *http.Request, args []string) {
+	if _, ok := f.dnsZones[args[0]]; !ok {
+		fakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	records := []*models.DNSRecord{}
+	for _, rec := range f.dnsRecords {
+		if rec.DNSZoneID == args[0] {
+			records = append(records, rec)
+		}
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].ID < records[j].ID })
+	fakeJSON(w, http.StatusOK, records)
+}
+
+func (f *fakeAPI) createDNSRecord(w http.ResponseWriter, r *http.Request, args []string) {
+	zone, ok := f.dnsZones[args[0]]
+	if !ok {
+		fakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	var create models.DNSRecordCreate
+	if !fakeDecode(w, r, &create) {
+		return
+	}
+
+	record := &models.DNSRecord{
+		ID:        f.newID(),
+		DNSZoneID: zone.ID,
+		SiteID:    zone.SiteID,
+		Hostname:  create.Hostname,
+		Type:      create.Type,
+		Value:     create.Value,
+		TTL:       create.TTL,
+		Priority:  create.Priority,
+	}
+	if record.TTL == 0 {
+		record.TTL = 3600
+	}
+	f.dnsRecords[record.ID] = record
+	fakeJSON(w, http.StatusCreated, record)
+}
+
+// Returns the DNS record of a zone, if any.
+func (f *fakeAPI) dnsRecord(zoneID, id string) *models.DNSRecord {
+	record, ok := f.dnsRecords[id]
+	if !ok || record.DNSZoneID != zoneID {
+		return nil
+	}
+	return record
+}
+
+func (f *fakeAPI) getDNSRecord(w http.ResponseWriter, r *http.Request, args []string) {
+	record := f.dnsRecord(args[0], args[1])
+	if record == nil {
+		fakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	fakeJSON(w, http.StatusOK, record)
+}
+
+func (f *fakeAPI) deleteDNSRecord(w http.ResponseWriter, r *http.Request, args []string) {
+	if f.dnsRecord(args[0], args[1]) == nil {
+		fakeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	delete(f.dnsRecords, args[1])
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func TestFakeAPI(t *testing.T) {
+	f := newFakeAPI()
+	defer f.Close()
+	meta := testFakeAPIMeta(t, f, 0)
+	c := context.Background()
+
+	createSite := operations.NewCreateSiteInTeamParamsWithContext(c)
+	createSite.AccountSlug = "fake-team"
+	createSite.Site = &models.SiteSetup{Site: models.Site{Name: "tubes"}}
+	site, err := meta.Netlify.Operations.CreateSiteInTeam(createSite, meta.AuthInfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if site.Payload.Name != "tubes" || site.Payload.AccountSlug != "fake-team" {
+		t.Fatalf("unexpected site %+v", site.Payload)
+	}
+
+	createHook := operations.NewCreateSiteBuildHookParamsWithContext(c)
+	createHook.SiteID = site.Payload.ID
+	createHook.BuildHook = &models.BuildHookSetup{Title: "tubes", Branch: "main"}
+	hook, err := meta.Netlify.Operations.CreateSiteBuildHook(createHook, meta.AuthInfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Names are unique.
+	if _, err := meta.Netlify.Operations.CreateSiteInTeam(createSite, meta.AuthInfo); apiErrorStatus(err) != http.StatusUnprocessableEntity {
+		t.Fatalf("expected a duplicate name to be rejected, got %v", err)
+	}
+
+	// Deleting the site deletes its build hooks.
+	deleteSite := operations.NewDeleteSiteParamsWithContext(c)
+	deleteSite.SiteID = site.Payload.ID
+	if _, err := meta.Netlify.Operations.DeleteSite(deleteSite, meta.AuthInfo); err != nil {
+		t.Fatal(err)
+	}
+
+	getHook := operations.NewGetSiteBuildHookParamsWithContext(c)
+	getHook.SiteID = site.Payload.ID
+	getHook.ID = hook.Payload.ID
+	if _, err := meta.Netlify.Operations.GetSiteBuildHook(getHook, meta.AuthInfo); !isNotFound(err) {
+		t.Fatalf("expected the build hook to be deleted, got %v", err)
+	}
+}
+
+func TestFakeAPI_faults(t *testing.T) {
+	f := newFakeAPI()
+	defer f.Close()
+	c := context.Background()
+
+	// Faults are retried until they are used up.
+	f.injectFault(http.MethodPost, "/sites", http.StatusTooManyRequests, 1)
+	f.injectFault(http.MethodGet, "/sites/", http.StatusServiceUnavailable, 1)
+
+	meta := testFakeAPIMeta(t, f, 2)
+	site, err := meta.Netlify.Operations.CreateSite(operations.NewCreateSiteParamsWithContext(c), meta.AuthInfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	getSite := operations.NewGetSiteParamsWithContext(c)
+	getSite.SiteID = site.Payload.ID
+	if _, err := meta.Netlify.Operations.GetSite(getSite, meta.AuthInfo); err != nil {
+		t.Fatal(err)
+	}
+	if n := f.pendingFaults(); n != 0 {
+		t.Fatalf("expected all faults to be returned, %d are pending", n)
+	}
+
+	// Without retries, faults are returned as errors.
+	f.injectFault(http.MethodGet, "/sites/", http.StatusInternalServerError, 1)
+	meta = testFakeAPIMeta(t, f, 0)
+	if _, err := meta.Netlify.Operations.GetSite(getSite, meta.AuthInfo); apiErrorStatus(err) != http.StatusInternalServerError {
+		t.Fatalf("expected an internal server error, got %v", err)
+	}
+}
+
+func TestFakeAPI_unauthorized(t *testing.T) {
+	f := newFakeAPI()
+	defer f.Close()
+
+	config := Config{Token: "invalid", BaseURL: f.BaseURL()}
+	metaRaw, err := config.Client(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := getCurrentUser(context.Background(), metaRaw.(*Meta)); apiErrorStatus(err) != http.StatusUnauthorized {
+		t.Fatalf("expected an unauthorized error, got %v", err)
+	}
+}
+
+// Returns a client of the fake API.
+func testFakeAPIMeta(t *testing.T, f *fakeAPI, maxRetries int) *Meta {
+	config := Config{Token: fakeAPIToken, BaseURL: f.BaseURL(), MaxRetries: maxRetries}
+	meta, err := config.Client(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return meta.(*Meta)
+}
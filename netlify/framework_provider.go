@@ -0,0 +1,186 @@
+package netlify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	providerschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ProviderServer returns the provider server, which muxes the SDK provider
+// with the framework provider. Resources are moved to the framework provider
+// one at a time, so both serve resources during the transition.
+func ProviderServer(ctx context.Context, version string) (func() tfprotov5.ProviderServer, error) {
+	shared := newSharedMeta()
+	return muxProviderServer(ctx, newProvider(version, shared)(), newFrameworkProvider(version, shared))
+}
+
+func muxProviderServer(ctx context.Context, sdkProvider *schema.Provider, frameworkProvider provider.Provider) (func() tfprotov5.ProviderServer, error) {
+	server, err := tf5muxserver.NewMuxServer(ctx,
+		sdkProvider.GRPCProvider,
+		providerserver.NewProtocol5(frameworkProvider),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return server.ProviderServer, nil
+}
+
+// frameworkProvider is the part of the provider implemented with the plugin
+// framework.
+type frameworkProvider struct {
+	version string
+	shared  *sharedMeta
+}
+
+func newFrameworkProvider(version string, shared *sharedMeta) provider.Provider {
+	return &frameworkProvider{version: version, shared: shared}
+}
+
+type frameworkProviderModel struct {
+	Token              types.String `tfsdk:"token"`
+	UserID             types.String `tfsdk:"user_id"`
+	ClientID           types.String `tfsdk:"client_id"`
+	CredentialsFile    types.String `tfsdk:"credentials_file"`
+	BaseURL            types.String `tfsdk:"base_url"`
+	DefaultAccountSlug types.String `tfsdk:"default_account_slug"`
+	MaxRetries         types.Int64  `tfsdk:"max_retries"`
+	RequestTimeout     types.String `tfsdk:"request_timeout"`
+}
+
+func (p *frameworkProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "netlify"
+	resp.Version = p.version
+}
+
+// The muxed providers must have the same schema, so it is converted from the
+// SDK provider's.
+func (p *frameworkProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+	attributes := map[string]providerschema.Attribute{}
+	for name, s := range newProvider(p.version, p.shared)().Schema {
+		switch s.Type {
+		case schema.TypeString:
+			attributes[name] = providerschema.StringAttribute{
+				MarkdownDescription: s.Description,
+				Optional:            s.Optional,
+				Sensitive:           s.Sensitive,
+			}
+		case schema.TypeInt:
+			attributes[name] = providerschema.Int64Attribute{
+				MarkdownDescription: s.Description,
+				Optional:            s.Optional,
+				Sensitive:           s.Sensitive,
+			}
+		default:
+			resp.Diagnostics.AddError("Unsupported provider attribute", fmt.Sprintf("Attribute %q has unsupported type %s", name, s.Type))
+		}
+	}
+
+	resp.Schema = providerschema.Schema{Attributes: attributes}
+}
+
+// Configures the client like the SDK provider does, including its defaults
+// from environment variables.
+func (p *frameworkProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var model frameworkProviderModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	values := map[string]attr.Value{
+		"token":                model.Token,
+		"user_id":              model.UserID,
+		"client_id":            model.ClientID,
+		"credentials_file":     model.CredentialsFile,
+		"base_url":             model.BaseURL,
+		"default_account_slug": model.DefaultAccountSlug,
+		"max_retries":          model.MaxRetries,
+		"request_timeout":      model.RequestTimeout,
+	}
+	config, err := providerConfig(func(attribute string) (string, bool) {
+		switch v := values[attribute].(type) {
+		case types.String:
+			return v.ValueString(), !v.IsNull() && !v.IsUnknown()
+		case types.Int64:
+			return strconv.FormatInt(v.ValueInt64(), 10), !v.IsNull() && !v.IsUnknown()
+		}
+		return "", false
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Netlify provider configuration", err.Error())
+		return
+	}
+
+	meta, err := p.shared.configure(ctx, config)
+	var credsErr *invalidCredentialsError
+	if errors.As(err, &credsErr) {
+		resp.Diagnostics.AddError("Invalid Netlify credentials.", credsErr.Error())
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error configuring the Netlify provider", err.Error())
+		return
+	}
+
+	resp.DataSourceData = meta
+	resp.ResourceData = meta
+}
+
+func (p *frameworkProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		newEnvVarResource,
+		newSiteResource,
+	}
+}
+
+func (p *frameworkProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+// Returns the client the provider was configured with, which is nil while
+// the provider has not been configured yet.
+func frameworkMeta(providerData any, diags *fwdiag.Diagnostics) *Meta {
+	if providerData == nil {
+		return nil
+	}
+
+	meta, ok := providerData.(*Meta)
+	if !ok {
+		diags.AddError("Unexpected provider data", fmt.Sprintf("Expected *Meta, got %T", providerData))
+	}
+	return meta
+}
+
+// Adds an error to framework diagnostics, like apiErrorDiags does for the
+// SDK. Client errors are attributed to the path, unless it is empty.
+func addAPIError(diags *fwdiag.Diagnostics, err error, p path.Path) {
+	summary, detail, status := apiErrorMessage(err)
+	if isClientError(status) && !p.Equal(path.Empty()) {
+		diags.AddAttributeError(p, summary, detail)
+		return
+	}
+	diags.AddError(summary, detail)
+}
+
+// Returns a string value that is null when empty, as the API does not tell
+// unset strings from empty ones.
+func nullableString(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
@@ -2,9 +2,16 @@ package netlify
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/netlify/open-api/v2/go/plumbing/operations"
 )
 
 func init() {
@@ -24,39 +31,98 @@ func init() {
 }
 
 func New(version string) func() *schema.Provider {
+	return newProvider(version, newSharedMeta())
+}
+
+// Returns the SDK provider, which shares its client with the framework
+// provider it is muxed with.
+func newProvider(version string, shared *sharedMeta) func() *schema.Provider {
 	return func() *schema.Provider {
 		p := &schema.Provider{
 			Schema: map[string]*schema.Schema{
 				"token": {
 					Type:        schema.TypeString,
-					Required:    true,
-					DefaultFunc: schema.EnvDefaultFunc("NETLIFY_TOKEN", nil),
-					Description: "The OAuth token used to connect to Netlify.",
+					Optional:    true,
+					Description: "The OAuth token used to connect to Netlify. If neither this nor `client_id` is set, the token of the Netlify CLI's login is used.",
+				},
+
+				"user_id": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The ID of the Netlify CLI user whose token is used when neither `token` nor `client_id` is set. Defaults to the CLI's current user.",
+				},
+
+				"client_id": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The client ID of an OAuth application to log in with when no `token` is set. The first run fails with a link to authorize the login, the next run waits for it to be authorized, and later runs use the cached access token.",
+				},
+
+				"credentials_file": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The file where the access token of a `client_id` login is cached. Defaults to `terraform-provider-netlify/credentials.json` in the user's configuration directory. Delete the token from it to log in again.",
 				},
 
 				"base_url": {
 					Type:        schema.TypeString,
 					Optional:    true,
-					DefaultFunc: schema.EnvDefaultFunc("NETLIFY_BASE_URL", defaultBaseUrl),
 					Description: "The Netlify Base API URL",
 				},
+
+				"default_account_slug": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The slug of the account used by resources whose account is not set, such as `netlify_site` and `netlify_environment_variable`.",
+				},
+
+				"max_retries": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(0),
+					Description:  "How often a request that was rate limited or failed with a server error is retried. Only idempotent requests are retried after a server error.",
+				},
+
+				"request_timeout": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validateDuration,
+					Description:  "How long a request may take, including retries and waiting for the rate limit to reset, e.g. `90s`.",
+				},
 			},
 			DataSourcesMap: map[string]*schema.Resource{
-				"netlify_site": dataSourceSite(),
+				"netlify_build_hooks":      dataSourceBuildHooks(),
+				"netlify_current_user":     dataSourceCurrentUser(),
+				"netlify_deploy_keys":      dataSourceDeployKeys(),
+				"netlify_form_submissions": dataSourceFormSubmissions(),
+				"netlify_forms":            dataSourceForms(),
+				"netlify_hooks":            dataSourceHooks(),
+				"netlify_plugin_runs":      dataSourcePluginRuns(),
+				"netlify_services":         dataSourceServices(),
+				"netlify_site":             dataSourceSite(),
 			},
 			ResourcesMap: map[string]*schema.Resource{
-				"netlify_build_hook":                 resourceBuildHook(),
-				"netlify_branch_deploy":              resourceBranchDeploy(),
-				"netlify_deploy_key":                 resourceDeployKey(),
-				"netlify_hook":                       resourceHook(),
-				"netlify_site":                       resourceSite(),
-				"netlify_environment_variable":       resourceEnvVar(),
-				"netlify_environment_variable_value": resourceEnvVarValue(),
-				"netlify_dns_zone":                   resourceDnsZone(),
-				"netlify_dns_record":                 resourceDnsRecord(),
+				"netlify_build_hook":                        resourceBuildHook(),
+				"netlify_build_hook_trigger":                resourceBuildHookTrigger(),
+				"netlify_branch_deploy":                     resourceBranchDeploy(),
+				"netlify_deploy_key":                        resourceDeployKey(),
+				"netlify_form":                              resourceForm(),
+				"netlify_form_submissions_purge":            resourceFormSubmissionsPurge(),
+				"netlify_hook":                              resourceHook(),
+				"netlify_notification_email":                resourceNotificationEmail(),
+				"netlify_notification_github_commit_status": resourceNotificationGithubCommitStatus(),
+				"netlify_notification_slack":                resourceNotificationSlack(),
+				"netlify_service_instance":                  resourceServiceInstance(),
+				"netlify_site_asset":                        resourceSiteAsset(),
+				"netlify_site_branch_deploys":               resourceSiteBranchDeploys(),
+				"netlify_site_build":                        resourceSiteBuild(),
+				"netlify_site_plugin":                       resourceSitePlugin(),
+				"netlify_environment_variable_value":        resourceEnvVarValue(),
+				"netlify_dns_zone":                          resourceDnsZone(),
+				"netlify_dns_record":                        resourceDnsRecord(),
 			},
 		}
-		p.ConfigureContextFunc = configure(version, p)
+		p.ConfigureContextFunc = configure(version, shared)
 		return p
 	}
 }
@@ -65,13 +131,149 @@ func New(version string) func() *schema.Provider {
 const defaultBaseUrl = "https://api.netlify.com/api/v1"
 
 // configures the Netlify context to use with the provider
-func configure(version string, p *schema.Provider) func(context.Context, *schema.ResourceData) (any, diag.Diagnostics) {
+func configure(version string, shared *sharedMeta) func(context.Context, *schema.ResourceData) (any, diag.Diagnostics) {
 	return func(c context.Context, d *schema.ResourceData) (any, diag.Diagnostics) {
-		config := Config{
-			Token:   d.Get("token").(string),
-			BaseURL: d.Get("base_url").(string),
+		config, err := providerConfig(func(attribute string) (string, bool) {
+			return fmt.Sprint(d.Get(attribute)), isAttributeConfigured(d, attribute)
+		})
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		meta, err := shared.configure(c, config)
+		var credsErr *invalidCredentialsError
+		if errors.As(err, &credsErr) {
+			return nil, diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Invalid Netlify credentials.",
+					Detail:   credsErr.Error(),
+				},
+			}
+		}
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		return meta, nil
+	}
+}
+
+// Returns whether the provider attribute is set in the configuration, which
+// is missing when the provider is configured directly rather than over gRPC.
+func isAttributeConfigured(d *schema.ResourceData, attribute string) bool {
+	if raw := d.GetRawConfig(); !raw.IsNull() {
+		return !raw.GetAttr(attribute).IsNull()
+	}
+
+	_, ok := d.GetOk(attribute)
+	return ok
+}
+
+// The environment variables and defaults of provider attributes that are not
+// set. They are applied on configure rather than with a DefaultFunc, as the
+// muxed providers must agree on the prepared provider configuration.
+var providerEnvDefaults = map[string]struct{ env, def string }{
+	"token":                {"NETLIFY_TOKEN", ""},
+	"user_id":              {"NETLIFY_USER_ID", ""},
+	"client_id":            {"NETLIFY_CLIENT_ID", ""},
+	"credentials_file":     {"NETLIFY_CREDENTIALS_FILE", ""},
+	"base_url":             {"NETLIFY_BASE_URL", defaultBaseUrl},
+	"default_account_slug": {"NETLIFY_DEFAULT_ACCOUNT_SLUG", ""},
+	"max_retries":          {"NETLIFY_MAX_RETRIES", "5"},
+	"request_timeout":      {"NETLIFY_REQUEST_TIMEOUT", "5m"},
+}
+
+// Returns the client configuration for the provider attributes. get returns
+// the configured value of an attribute, and whether it is set at all.
+func providerConfig(get func(attribute string) (string, bool)) (Config, error) {
+	value := func(attribute string) string {
+		if v, ok := get(attribute); ok {
+			return v
+		}
+		if v := os.Getenv(providerEnvDefaults[attribute].env); v != "" {
+			return v
+		}
+		return providerEnvDefaults[attribute].def
+	}
+
+	maxRetries, err := strconv.Atoi(value("max_retries"))
+	if err != nil || maxRetries < 0 {
+		return Config{}, fmt.Errorf("max_retries must be a non-negative integer, got %q", value("max_retries"))
+	}
+	requestTimeout, err := time.ParseDuration(value("request_timeout"))
+	if err != nil {
+		return Config{}, fmt.Errorf("request_timeout must be a duration: %s", err)
+	}
+
+	return Config{
+		Token:           value("token"),
+		BaseURL:         value("base_url"),
+		ClientID:        value("client_id"),
+		CredentialsFile: value("credentials_file"),
+		UserID:          value("user_id"),
+
+		DefaultAccountSlug: value("default_account_slug"),
+		MaxRetries:         maxRetries,
+		RequestTimeout:     requestTimeout,
+	}, nil
+}
+
+// invalidCredentialsError is returned when Netlify rejects the credentials.
+type invalidCredentialsError struct {
+	status int
+}
+
+func (e *invalidCredentialsError) Error() string {
+	return fmt.Sprintf("Netlify rejected the credentials with status %d. Check that the token is valid and has not been revoked. A token cached by a client_id login can be removed from the credentials file to log in again.", e.status)
+}
+
+// Returns the client for a provider configuration, shared by the SDK and
+// framework providers. The credentials and the default account are checked
+// now, rather than failing in the first resource that uses them.
+func configureMeta(c context.Context, config Config) (*Meta, error) {
+	var err error
+	if config.CredentialsFile == "" && config.Token == "" {
+		if config.CredentialsFile, err = defaultCredentialsFile(); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.Token == "" && config.ClientID == "" {
+		if config.CLIConfigFile, err = defaultCLIConfigFile(); err != nil {
+			return nil, err
+		}
+	}
+
+	client, err := config.Client(c)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := client.(*Meta)
+	if _, err := getCurrentUser(c, meta); err != nil {
+		if status := apiErrorStatus(err); status == 401 || status == 403 {
+			return nil, &invalidCredentialsError{status: status}
 		}
-		client, err := config.Client()
-		return client, diag.FromErr(err)
+
+		return nil, fmt.Errorf("Error reading the current Netlify user: %s", err)
+	}
+
+	if config.DefaultAccountSlug != "" {
+		params := operations.NewGetAccountParamsWithContext(c)
+		params.AccountID = config.DefaultAccountSlug
+		if _, err := meta.Netlify.Operations.GetAccount(params, meta.AuthInfo); err != nil {
+			return nil, fmt.Errorf("Error reading default account %q: %s", config.DefaultAccountSlug, err)
+		}
+	}
+
+	return meta, nil
+}
+
+// Validates that a string is a duration, e.g. "90s".
+func validateDuration(v interface{}, k string) (ws []string, es []error) {
+	if _, err := time.ParseDuration(v.(string)); err != nil {
+		es = append(es, fmt.Errorf("%q must be a duration: %s", k, err))
 	}
+	return
 }
@@ -1,10 +1,23 @@
 package netlify
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"testing"
 
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-exec/tfexec"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -14,18 +27,46 @@ import (
 // can use its Netlify client to make our API calls for checking resources.
 var testAccProvider *schema.Provider
 
-// providerFactories are used to instantiate a provider during acceptance testing.
-// The factory function will be invoked for every Terraform CLI command executed
-// to create a provider server to which the CLI can reattach.
-var providerFactories = map[string]func() (*schema.Provider, error){
-	"netlify": func() (*schema.Provider, error) {
-		return testAccProvider, nil
+// testAccSharedMeta is shared by the muxed providers, like in ProviderServer.
+var testAccSharedMeta = newSharedMeta()
+
+// protoV5ProviderFactories are used to instantiate a provider during acceptance
+// testing. The factory function will be invoked for every Terraform CLI command
+// executed to create a provider server to which the CLI can reattach.
+var protoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
+	"netlify": func() (tfprotov5.ProviderServer, error) {
+		server, err := muxProviderServer(context.Background(), testAccProvider, newFrameworkProvider("dev", testAccSharedMeta))
+		if err != nil {
+			return nil, err
+		}
+		return server(), nil
 	},
 }
 var netlify *schema.Provider
 
+// testAccFakeAPI is the fake API the acceptance tests run against, unless
+// NETLIFY_TOKEN is set to run them against the real one.
+var testAccFakeAPI *fakeAPI
+
 func init() {
-	testAccProvider = New("dev")()
+	testAccProvider = newProvider("dev", testAccSharedMeta)()
+}
+
+func TestMain(m *testing.M) {
+	if os.Getenv(resource.EnvTfAcc) == "" || os.Getenv("NETLIFY_TOKEN") != "" {
+		os.Exit(m.Run())
+	}
+
+	testAccFakeAPI = newFakeAPI()
+	os.Setenv("NETLIFY_TOKEN", fakeAPIToken)
+	os.Setenv("NETLIFY_BASE_URL", testAccFakeAPI.BaseURL())
+	if os.Getenv("NETLIFY_TEST_ACCOUNT_SLUG") == "" {
+		os.Setenv("NETLIFY_TEST_ACCOUNT_SLUG", testAccFakeAPI.accounts[1].Slug)
+	}
+
+	code := m.Run()
+	testAccFakeAPI.Close()
+	os.Exit(code)
 }
 
 func TestProvider(t *testing.T) {
@@ -34,12 +75,217 @@ func TestProvider(t *testing.T) {
 	}
 }
 
+func TestProvider_invalidCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"code": 401, "message": "Access Denied"}`))
+	}))
+	defer server.Close()
+
+	p := New("dev")()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"token":    "invalid",
+		"base_url": server.URL,
+	}))
+	if !diags.HasError() || diags[0].Summary != "Invalid Netlify credentials." {
+		t.Fatalf("expected an invalid credentials error, got %v", diags)
+	}
+}
+
+func TestProviderServer(t *testing.T) {
+	server, err := ProviderServer(context.Background(), "dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The mux server fails if the muxed providers' schemas differ.
+	resp, err := server().GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range resp.Diagnostics {
+		t.Errorf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+	for _, name := range []string{"netlify_environment_variable", "netlify_hook", "netlify_site"} {
+		if _, ok := resp.ResourceSchemas[name]; !ok {
+			t.Errorf("expected a schema for %s", name)
+		}
+	}
+}
+
+// Upgrades the raw JSON state of a resource with the muxed provider server,
+// returning the upgraded attributes.
+func testUpgradeResourceState(t *testing.T, typeName string, version int64, state string) map[string]tftypes.Value {
+	t.Helper()
+	ctx := context.Background()
+	server, err := ProviderServer(ctx, "dev")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemaResp, err := server().GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server().UpgradeResourceState(ctx, &tfprotov5.UpgradeResourceStateRequest{
+		TypeName: typeName,
+		Version:  version,
+		RawState: &tfprotov5.RawState{JSON: []byte(state)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range resp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+
+	value, err := resp.UpgradedState.Unmarshal(schemaResp.ResourceSchemas[typeName].ValueType())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var attributes map[string]tftypes.Value
+	if err := value.As(&attributes); err != nil {
+		t.Fatal(err)
+	}
+	return attributes
+}
+
 func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("NETLIFY_TOKEN"); v == "" {
 		t.Fatal("NETLIFY_TOKEN must be set for acceptance tests")
 	}
 }
 
+// Returns a client configured from the environment like the provider's, for
+// tests that use the API before the provider is configured.
+func testAccMeta(t *testing.T) *Meta {
+	config, err := providerConfig(func(string) (string, bool) { return "", false })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	meta, err := configureMeta(context.Background(), config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return meta
+}
+
+// Skips tests that need a newer Terraform CLI than the one in
+// TF_ACC_TERRAFORM_PATH. Otherwise the latest version is installed.
+func testAccRequireTerraformVersion(t *testing.T, minimum string) {
+	path := os.Getenv("TF_ACC_TERRAFORM_PATH")
+	if path == "" {
+		return
+	}
+
+	tf, err := tfexec.NewTerraform(t.TempDir(), path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, _, err := tf.Version(context.Background(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.LessThan(version.Must(version.NewVersion(minimum))) {
+		t.Skipf("This test requires Terraform %s or later, got %s", minimum, v)
+	}
+}
+
+// Returns the configuration Terraform generates for the import blocks of a
+// configuration. The test harness cannot plan with -generate-config-out, so
+// the Terraform CLI is run directly, attached to a provider served by the
+// test like the harness does.
+func testAccGenerateConfig(t *testing.T, config string) string {
+	path := os.Getenv("TF_ACC_TERRAFORM_PATH")
+	if path == "" {
+		var err error
+		if path, err = exec.LookPath("terraform"); err != nil {
+			t.Skip("Set TF_ACC_TERRAFORM_PATH to generate configuration")
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	server, err := muxProviderServer(ctx, testAccProvider, newFrameworkProvider("dev", testAccSharedMeta))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reattachCh := make(chan *plugin.ReattachConfig)
+	closeCh := make(chan struct{})
+	go tf5server.Serve("registry.terraform.io/hashicorp/netlify", server,
+		tf5server.WithDebug(ctx, reattachCh, closeCh),
+		tf5server.WithGoPluginLogger(hclog.NewNullLogger()),
+		tf5server.WithoutLogStderrOverride(),
+	)
+	defer func() {
+		cancel()
+		<-closeCh
+	}()
+
+	reattach := <-reattachCh
+	reattachInfo, err := json.Marshal(map[string]tfexec.ReattachConfig{
+		"registry.terraform.io/hashicorp/netlify": {
+			Protocol:        string(reattach.Protocol),
+			ProtocolVersion: reattach.ProtocolVersion,
+			Pid:             reattach.Pid,
+			Test:            reattach.Test,
+			Addr: tfexec.ReattachConfigAddr{
+				Network: reattach.Addr.Network(),
+				String:  reattach.Addr.String(),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "main.tf"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "-input=false"},
+		{"plan", "-input=false", "-generate-config-out=generated.tf"},
+	} {
+		cmd := exec.Command(path, args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"CHECKPOINT_DISABLE=1",
+			"PLUGIN_PROTOCOL_VERSIONS=5",
+			"TF_REATTACH_PROVIDERS="+string(reattachInfo),
+		)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("terraform %s: %s\n%s", args[0], err, out)
+		}
+	}
+
+	generated, err := os.ReadFile(filepath.Join(dir, "generated.tf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(generated)
+}
+
+// Skips tests of APIs that the fake API does not implement, unless they run
+// against the real API.
+func testAccSkipFake(t *testing.T) {
+	if testAccFakeAPI != nil {
+		t.Skip("Set NETLIFY_TOKEN to run this test against the Netlify API")
+	}
+}
+
+// Skips tests that inject faults into the fake API when running against the
+// real one.
+func testAccRequireFake(t *testing.T) {
+	if testAccFakeAPI == nil {
+		t.Skip("This test only runs against the fake API")
+	}
+}
+
 // common assertion test case
 func testAccAssert(msg string, f func() bool) resource.TestCheckFunc {
 	return func(*terraform.State) error {
@@ -1,10 +1,11 @@
 package netlify
 
 import (
-	"errors"
-	"fmt"
-	"sync"
+	"context"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/netlify/open-api/v2/go/models"
 	"github.com/netlify/open-api/v2/go/plumbing/operations"
@@ -12,12 +13,19 @@ import (
 
 func resourceBranchDeploy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBranchDeployCreate,
-		Read:   resourceBranchDeployRead,
-		Update: resourceBranchDeployUpdate,
-		Delete: resourceBranchDeployDelete,
+		CreateContext: resourceBranchDeployCreate,
+		ReadContext:   resourceBranchDeployRead,
+		UpdateContext: resourceBranchDeployUpdate,
+		DeleteContext: resourceBranchDeployDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -35,30 +43,27 @@ func resourceBranchDeploy() *schema.Resource {
 	}
 }
 
-var mutex sync.Mutex
-
-func resourceBranchDeployCreate(d *schema.ResourceData, metaRaw interface{}) error {
+func resourceBranchDeployCreate(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
 	siteId := d.Get("site_id").(string)
 
-	mutex.Lock()
-	defer mutex.Unlock()
-
 	meta := metaRaw.(*Meta)
-	repoBranch, branches, err := resourceBranchDeploy_getBranchAndBranches(d, meta)
+	meta.SiteLocks.Lock(siteId)
+	defer meta.SiteLocks.Unlock(siteId)
+	repoBranch, branches, err := resourceBranchDeploy_getBranchAndBranches(c, d, meta)
 	if err != nil {
-		return err
+		return apiErrorDiags(err, cty.GetAttrPath("site_id"))
 	}
 
 	branch := d.Get("branch").(string)
 
 	for _, existing := range branches {
 		if existing == branch {
-			return errors.New(fmt.Sprintf("Branch deploy %s already exists", branch))
+			return diag.Errorf("Branch deploy %s already exists", branch)
 		}
 	}
 	branches = append(branches, branch, repoBranch)
 
-	patch := operations.NewUpdateSiteParams()
+	patch := operations.NewUpdateSiteParamsWithContext(c)
 	patch.SiteID = siteId
 	patch.Site = &models.SiteSetup{
 		Site: models.Site{
@@ -70,7 +75,7 @@ func resourceBranchDeployCreate(d *schema.ResourceData, metaRaw interface{}) err
 	_, err = meta.Netlify.Operations.UpdateSite(patch, meta.AuthInfo)
 
 	if err != nil {
-		return err
+		return apiErrorDiags(err, cty.GetAttrPath("site_id"))
 	}
 
 	d.SetId(branch)
@@ -78,19 +83,18 @@ func resourceBranchDeployCreate(d *schema.ResourceData, metaRaw interface{}) err
 	return nil
 }
 
-func resourceBranchDeployRead(d *schema.ResourceData, metaRaw interface{}) error {
+func resourceBranchDeployRead(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
 	siteId := d.Get("site_id").(string)
 
-	mutex.Lock()
-	defer mutex.Unlock()
-
 	meta := metaRaw.(*Meta)
+	meta.SiteLocks.Lock(siteId)
+	defer meta.SiteLocks.Unlock(siteId)
 
-	params := operations.NewGetSiteParams()
+	params := operations.NewGetSiteParamsWithContext(c)
 	params.SiteID = siteId
 	resp, err := meta.Netlify.Operations.GetSite(params, meta.AuthInfo)
 	if err != nil {
-		return err
+		return apiErrorDiags(err, nil)
 	}
 
 	for _, b := range resp.Payload.BuildSettings.AllowedBranches {
@@ -105,19 +109,18 @@ func resourceBranchDeployRead(d *schema.ResourceData, metaRaw interface{}) error
 	return nil
 }
 
-func resourceBranchDeployUpdate(d *schema.ResourceData, metaRaw interface{}) error {
+func resourceBranchDeployUpdate(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
 	siteId := d.Get("site_id").(string)
 
-	mutex.Lock()
-	defer mutex.Unlock()
-
 	meta := metaRaw.(*Meta)
+	meta.SiteLocks.Lock(siteId)
+	defer meta.SiteLocks.Unlock(siteId)
 
 	oldBranch := d.Id()
 
-	b, branches, err := resourceBranchDeploy_getBranchAndBranches(d, meta)
+	b, branches, err := resourceBranchDeploy_getBranchAndBranches(c, d, meta)
 	if err != nil {
-		return err
+		return apiErrorDiags(err, cty.GetAttrPath("site_id"))
 	}
 
 	var newBranches []string
@@ -128,7 +131,7 @@ func resourceBranchDeployUpdate(d *schema.ResourceData, metaRaw interface{}) err
 	}
 	newBranches = append(newBranches, b, d.Get("branch").(string))
 
-	params := operations.NewUpdateSiteParams()
+	params := operations.NewUpdateSiteParamsWithContext(c)
 	params.SiteID = siteId
 
 	params.Site = &models.SiteSetup{
@@ -142,25 +145,24 @@ func resourceBranchDeployUpdate(d *schema.ResourceData, metaRaw interface{}) err
 	_, err = meta.Netlify.Operations.UpdateSite(params, meta.AuthInfo)
 
 	if err != nil {
-		return err
+		return apiErrorDiags(err, cty.GetAttrPath("site_id"))
 	}
 
 	return nil
 }
 
-func resourceBranchDeployDelete(d *schema.ResourceData, metaRaw interface{}) error {
+func resourceBranchDeployDelete(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
 	siteId := d.Get("site_id").(string)
 
-	mutex.Lock()
-	defer mutex.Unlock()
-
 	meta := metaRaw.(*Meta)
+	meta.SiteLocks.Lock(siteId)
+	defer meta.SiteLocks.Unlock(siteId)
 
 	oldBranch := d.Id()
 
-	b, branches, err := resourceBranchDeploy_getBranchAndBranches(d, meta)
+	b, branches, err := resourceBranchDeploy_getBranchAndBranches(c, d, meta)
 	if err != nil {
-		return err
+		return apiErrorDiags(err, nil)
 	}
 
 	var newBranches []string
@@ -171,7 +173,7 @@ func resourceBranchDeployDelete(d *schema.ResourceData, metaRaw interface{}) err
 	}
 	newBranches = append(newBranches, b)
 
-	params := operations.NewUpdateSiteParams()
+	params := operations.NewUpdateSiteParamsWithContext(c)
 	params.SiteID = siteId
 
 	params.Site = &models.SiteSetup{
@@ -185,14 +187,14 @@ func resourceBranchDeployDelete(d *schema.ResourceData, metaRaw interface{}) err
 	_, err = meta.Netlify.Operations.UpdateSite(params, meta.AuthInfo)
 
 	if err != nil {
-		return err
+		return apiErrorDiags(err, nil)
 	}
 
 	return nil
 }
 
-func resourceBranchDeploy_getBranchAndBranches(d *schema.ResourceData, meta *Meta) (string, []string, error) {
-	params := operations.NewGetSiteParams()
+func resourceBranchDeploy_getBranchAndBranches(c context.Context, d *schema.ResourceData, meta *Meta) (string, []string, error) {
+	params := operations.NewGetSiteParamsWithContext(c)
 	params.SiteID = d.Get("site_id").(string)
 	resp, err := meta.Netlify.Operations.GetSite(params, meta.AuthInfo)
 	if err != nil {
@@ -1,6 +1,11 @@
 package netlify
 
 import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/netlify/open-api/v2/go/models"
 	"github.com/netlify/open-api/v2/go/plumbing/operations"
@@ -8,12 +13,19 @@ import (
 
 func resourceBuildHook() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBuildHookCreate,
-		Read:   resourceBuildHookRead,
-		Update: resourceBuildHookUpdate,
-		Delete: resourceBuildHookDelete,
+		CreateContext: resourceBuildHookCreate,
+		ReadContext:   resourceBuildHookRead,
+		UpdateContext: resourceBuildHookUpdate,
+		DeleteContext: resourceBuildHookDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -33,6 +45,12 @@ func resourceBuildHook() *schema.Resource {
 			},
 
 			"url": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"created_at": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
@@ -40,35 +58,35 @@ func resourceBuildHook() *schema.Resource {
 	}
 }
 
-func resourceBuildHookCreate(d *schema.ResourceData, metaRaw interface{}) error {
-	params := operations.NewCreateSiteBuildHookParams()
+func resourceBuildHookCreate(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	params := operations.NewCreateSiteBuildHookParamsWithContext(c)
 	params.SiteID = d.Get("site_id").(string)
 	params.BuildHook = resourceBuildHookSetup_struct(d)
 
 	meta := metaRaw.(*Meta)
 	resp, err := meta.Netlify.Operations.CreateSiteBuildHook(params, meta.AuthInfo)
 	if err != nil {
-		return err
+		return apiErrorDiags(err, cty.GetAttrPath("site_id"))
 	}
 
 	d.SetId(resp.Payload.ID)
-	return resourceBuildHookRead(d, metaRaw)
+	return resourceBuildHookRead(c, d, metaRaw)
 }
 
-func resourceBuildHookRead(d *schema.ResourceData, metaRaw interface{}) error {
+func resourceBuildHookRead(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
 	meta := metaRaw.(*Meta)
-	params := operations.NewGetSiteBuildHookParams()
+	params := operations.NewGetSiteBuildHookParamsWithContext(c)
 	params.ID = d.Id()
 	params.SiteID = d.Get("site_id").(string)
 	resp, err := meta.Netlify.Operations.GetSiteBuildHook(params, meta.AuthInfo)
 	if err != nil {
 		// If it is a 404 it was removed remotely
-		if v, ok := err.(*operations.GetSiteBuildHookDefault); ok && v.Code() == 404 {
+		if isNotFound(err) {
 			d.SetId("")
 			return nil
 		}
 
-		return err
+		return apiErrorDiags(err, nil)
 	}
 
 	hook := resp.Payload
@@ -76,12 +94,13 @@ func resourceBuildHookRead(d *schema.ResourceData, metaRaw interface{}) error {
 	d.Set("branch", hook.Branch)
 	d.Set("title", hook.Title)
 	d.Set("url", hook.URL)
+	d.Set("created_at", hook.CreatedAt)
 
 	return nil
 }
 
-func resourceBuildHookUpdate(d *schema.ResourceData, metaRaw interface{}) error {
-	params := operations.NewUpdateSiteBuildHookParams()
+func resourceBuildHookUpdate(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	params := operations.NewUpdateSiteBuildHookParamsWithContext(c)
 	params.ID = d.Id()
 	params.SiteID = d.Get("site_id").(string)
 	params.BuildHook = resourceBuildHookSetup_struct(d)
@@ -89,19 +108,19 @@ func resourceBuildHookUpdate(d *schema.ResourceData, metaRaw interface{}) error
 	meta := metaRaw.(*Meta)
 	_, err := meta.Netlify.Operations.UpdateSiteBuildHook(params, meta.AuthInfo)
 	if err != nil {
-		return err
+		return apiErrorDiags(err, nil)
 	}
 
-	return resourceBuildHookRead(d, metaRaw)
+	return resourceBuildHookRead(c, d, metaRaw)
 }
 
-func resourceBuildHookDelete(d *schema.ResourceData, metaRaw interface{}) error {
+func resourceBuildHookDelete(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
 	meta := metaRaw.(*Meta)
-	params := operations.NewDeleteSiteBuildHookParams()
+	params := operations.NewDeleteSiteBuildHookParamsWithContext(c)
 	params.ID = d.Id()
 	params.SiteID = d.Get("site_id").(string)
 	_, err := meta.Netlify.Operations.DeleteSiteBuildHook(params, meta.AuthInfo)
-	return err
+	return apiErrorDiags(err, nil)
 }
 
 // Returns the BuildHook structure that can be used for creation or updating.
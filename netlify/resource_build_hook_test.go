@@ -15,9 +15,9 @@ func TestAccBuildHook(t *testing.T) {
 	resourceName := "netlify_build_hook.test"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
-		CheckDestroy:      testAccCheckBuildHookDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckBuildHookDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccBuildHookConfig,
@@ -43,9 +43,9 @@ func TestAccBuildHook_disappears(t *testing.T) {
 	}
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
-		CheckDestroy:      testAccCheckBuildHookDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckBuildHookDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccBuildHookConfig,
@@ -64,9 +64,9 @@ func TestAccBuildHook_updateBranch(t *testing.T) {
 	resourceName := "netlify_build_hook.test"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
-		CheckDestroy:      testAccCheckBuildHookDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckBuildHookDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccBuildHookConfig,
@@ -132,7 +132,7 @@ func testAccCheckBuildHookDestroy(s *terraform.State) error {
 		}
 
 		if err != nil {
-			if v, ok := err.(*operations.GetSiteBuildHookDefault); ok && v.Code() == 404 {
+			if isNotFound(err) {
 				return nil
 			}
 		}
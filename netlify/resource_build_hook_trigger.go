@@ -0,0 +1,108 @@
+package netlify
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceBuildHookTrigger() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Calls a build hook when created. Any change to the arguments, including `triggers`, calls the hook again. The build is started asynchronously; use `netlify_site_build` to wait for a build to finish.",
+		CreateContext: resourceBuildHookTriggerCreate,
+		ReadContext:   schema.NoopContext,
+		DeleteContext: resourceBuildHookTriggerDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"url": {
+				Type:        schema.TypeString,
+				Description: "The URL of the build hook, e.g. `netlify_build_hook.example.url`.",
+				Required:    true,
+				ForceNew:    true,
+				Sensitive:   true,
+			},
+
+			"trigger_title": {
+				Type:        schema.TypeString,
+				Description: "A title for the deploy started by the hook.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+
+			"clear_cache": {
+				Type:        schema.TypeBool,
+				Description: "Whether to clear the build cache before building.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+
+			"triggers": {
+				Type:        schema.TypeMap,
+				Description: "Arbitrary map of values that, when changed, will call the hook again.",
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceBuildHookTriggerCreate(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	meta := metaRaw.(*Meta)
+	u, err := url.Parse(d.Get("url").(string))
+	if err != nil {
+		return diag.Errorf("Error parsing build hook url: %s", err)
+	}
+
+	query := u.Query()
+	if v, ok := d.GetOk("trigger_title"); ok {
+		query.Set("trigger_title", v.(string))
+	}
+	if d.Get("clear_cache").(bool) {
+		query.Set("clear_cache", "true")
+	}
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, u.String(), nil)
+	if err != nil {
+		return apiErrorDiags(err, nil)
+	}
+	req.Header.Set("User-Agent", "Terraform")
+
+	resp, err := meta.HTTPClient.Do(req)
+	if err != nil {
+		// Don't leak the URL, which is a secret, in the error.
+		if v, ok := err.(*url.Error); ok {
+			err = v.Err
+		}
+		return diag.Errorf("Error calling build hook: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return diag.Errorf("Error calling build hook: %s: %s", resp.Status, body)
+	}
+
+	d.SetId(resource.UniqueId())
+	return nil
+}
+
+// The triggered build is left running; it belongs to the site, not the hook.
+func resourceBuildHookTriggerDelete(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
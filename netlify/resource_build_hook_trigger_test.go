@@ -0,0 +1,100 @@
+package netlify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// The hook URL is a secret, and hooks are not part of the API, so calling
+// one is neither logged nor retried like API requests.
+func TestBuildHookTrigger_plainClient(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+	t.Setenv("TF_LOG", "DEBUG")
+
+	config := Config{Token: "token", BaseURL: server.URL, MaxRetries: 3}
+	meta, err := config.Client(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := resourceBuildHookTrigger()
+	d := r.TestResourceData()
+	d.Set("url", server.URL+"/build_hooks/secret")
+	if diags := r.CreateContext(context.Background(), d, meta); !diags.HasError() {
+		t.Fatal("expected the failed hook to fail the create")
+	}
+
+	if requests != 1 {
+		t.Errorf("expected the hook to be called once, got %d requests", requests)
+	}
+	if strings.Contains(logs.String(), "secret") {
+		t.Errorf("expected the hook URL not to be logged, got %s", logs.String())
+	}
+}
+
+func TestAccBuildHookTrigger(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckBuildHookDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccBuildHookTriggerConfig, "one"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("netlify_build_hook.test", "created_at"),
+					resource.TestCheckResourceAttrSet("netlify_build_hook_trigger.test", "id"),
+				),
+			},
+
+			{
+				Config: fmt.Sprintf(testAccBuildHookTriggerConfig, "two"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netlify_build_hook_trigger.test", "triggers.version", "two"),
+				),
+			},
+		},
+	})
+}
+
+var testAccBuildHookTriggerConfig = `
+resource "netlify_site" "test" {
+	repo {
+		provider = "github"
+		repo_path = "mitchellh/fogli"
+		repo_branch = "master"
+	}
+}
+
+resource "netlify_build_hook" "test" {
+	site_id = "${netlify_site.test.id}"
+	branch = "master"
+	title = "tubes"
+}
+
+resource "netlify_build_hook_trigger" "test" {
+	url = netlify_build_hook.test.url
+	trigger_title = "triggered by terraform"
+
+	triggers = {
+		version = "%s"
+	}
+}
+`
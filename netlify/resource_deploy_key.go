@@ -1,65 +1,216 @@
 package netlify
 
 import (
+	"context"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/netlify/open-api/v2/go/plumbing/operations"
 )
 
 func resourceDeployKey() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDeployKeyCreate,
-		Read:   resourceDeployKeyRead,
-		Delete: resourceDeployKeyDelete,
+		CreateContext: resourceDeployKeyCreate,
+		ReadContext:   resourceDeployKeyRead,
+		UpdateContext: resourceDeployKeyUpdate,
+		DeleteContext: resourceDeployKeyDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
+			"site_id": {
+				Type:        schema.TypeString,
+				Description: "If provided, the key is set as the deploy key of this site. It is cleared on the site again when the key moves to another site or is destroyed.",
+				Optional:    true,
+			},
+
 			"public_key": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
-func resourceDeployKeyCreate(d *schema.ResourceData, metaRaw interface{}) error {
+func resourceDeployKeyCreate(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
 	meta := metaRaw.(*Meta)
 
 	resp, err := meta.Netlify.Operations.CreateDeployKey(
-		operations.NewCreateDeployKeyParams(), meta.AuthInfo)
+		operations.NewCreateDeployKeyParamsWithContext(c), meta.AuthInfo)
 	if err != nil {
-		return err
+		return apiErrorDiags(err, nil)
 	}
 
 	d.SetId(resp.Payload.ID)
 	d.Set("public_key", resp.Payload.PublicKey)
-	return nil
+
+	if v, ok := d.GetOk("site_id"); ok {
+		if err := resourceDeployKey_setSiteKey(c, meta, v.(string), d.Id()); err != nil {
+			return apiErrorDiags(err, cty.GetAttrPath("site_id"))
+		}
+	}
+
+	return resourceDeployKeyRead(c, d, metaRaw)
 }
 
-func resourceDeployKeyRead(d *schema.ResourceData, metaRaw interface{}) error {
+func resourceDeployKeyRead(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
 	meta := metaRaw.(*Meta)
-	params := operations.NewGetDeployKeyParams()
+	params := operations.NewGetDeployKeyParamsWithContext(c)
 	params.KeyID = d.Id()
 	resp, err := meta.Netlify.Operations.GetDeployKey(params, meta.AuthInfo)
 	if err != nil {
 		// Deleted remotely
-		if v, ok := err.(*operations.GetDeployKeyDefault); ok && v.Code() == 404 {
+		if isNotFound(err) {
 			d.SetId("")
 			return nil
 		}
 
-		return err
+		return apiErrorDiags(err, nil)
 	}
 
 	d.Set("public_key", resp.Payload.PublicKey)
+	d.Set("created_at", resp.Payload.CreatedAt)
+
+	// If the site no longer uses this key, clear the site so it is set again.
+	if v, ok := d.GetOk("site_id"); ok {
+		siteParams := operations.NewGetSiteParamsWithContext(c)
+		siteParams.SiteID = v.(string)
+		siteResp, err := meta.Netlify.Operations.GetSite(siteParams, meta.AuthInfo)
+		if err != nil {
+			if isNotFound(err) {
+				d.Set("site_id", "")
+				return nil
+			}
+
+			return apiErrorDiags(err, nil)
+		}
+
+		site := siteResp.Payload
+		if site.BuildSettings == nil || site.BuildSettings.DeployKeyID != d.Id() {
+			d.Set("site_id", "")
+		}
+	}
+
 	return nil
 }
 
-func resourceDeployKeyDelete(d *schema.ResourceData, metaRaw interface{}) error {
+func resourceDeployKeyUpdate(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	meta := metaRaw.(*Meta)
+	if d.HasChange("site_id") {
+		// The key is moved, rather than left on the site it was set on.
+		oldSite, newSite := d.GetChange("site_id")
+		if oldSite.(string) != "" {
+			if err := resourceDeployKey_clearSiteKey(c, meta, oldSite.(string), d.Id()); err != nil {
+				return apiErrorDiags(err, nil)
+			}
+		}
+		if newSite.(string) != "" {
+			if err := resourceDeployKey_setSiteKey(c, meta, newSite.(string), d.Id()); err != nil {
+				return apiErrorDiags(err, cty.GetAttrPath("site_id"))
+			}
+		}
+	}
+
+	return resourceDeployKeyRead(c, d, metaRaw)
+}
+
+func resourceDeployKeyDelete(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
 	meta := metaRaw.(*Meta)
-	params := operations.NewDeleteDeployKeyParams()
+	if v, ok := d.GetOk("site_id"); ok {
+		if err := resourceDeployKey_clearSiteKey(c, meta, v.(string), d.Id()); err != nil {
+			return apiErrorDiags(err, nil)
+		}
+	}
+
+	params := operations.NewDeleteDeployKeyParamsWithContext(c)
 	params.KeyID = d.Id()
 	_, err := meta.Netlify.Operations.DeleteDeployKey(params, meta.AuthInfo)
+	return apiErrorDiags(err, nil)
+}
+
+// Sets the deploy key used by a site to fetch its repository.
+func resourceDeployKey_setSiteKey(c context.Context, meta *Meta, siteID, keyID string) error {
+	meta.SiteLocks.Lock(siteID)
+	defer meta.SiteLocks.Unlock(siteID)
+
+	return resourceDeployKey_updateSite(c, meta, siteID, keyID)
+}
+
+// Clears the deploy key of a site, unless the site has been given another
+// key since. A site that no longer exists has no key to clear.
+func resourceDeployKey_clearSiteKey(c context.Context, meta *Meta, siteID, keyID string) error {
+	meta.SiteLocks.Lock(siteID)
+	defer meta.SiteLocks.Unlock(siteID)
+
+	params := operations.NewGetSiteParamsWithContext(c)
+	params.SiteID = siteID
+	resp, err := meta.Netlify.Operations.GetSite(params, meta.AuthInfo)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if resp.Payload.BuildSettings == nil || resp.Payload.BuildSettings.DeployKeyID != keyID {
+		return nil
+	}
+
+	return resourceDeployKey_updateSite(c, meta, siteID, "")
+}
+
+// Updates the deploy key of a site, clearing it if keyID is empty. The site
+// lock must be held.
+func resourceDeployKey_updateSite(c context.Context, meta *Meta, siteID, keyID string) error {
+	_, err := meta.Netlify.Transport.Submit(&runtime.ClientOperation{
+		ID:                 "updateSite",
+		Method:             "PATCH",
+		PathPattern:        "/sites/{site_id}",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             &siteDeployKeyParams{SiteID: siteID, KeyID: keyID},
+		Reader:             &operations.UpdateSiteReader{},
+		AuthInfo:           meta.AuthInfo,
+		Context:            c,
+	})
 	return err
 }
+
+// SiteSetup sends every build setting, including an empty allowed_branches
+// that would reset the branch deploys of the site, so only the deploy key is
+// sent instead.
+type siteDeployKeyParams struct {
+	SiteID string
+	KeyID  string
+}
+
+func (p *siteDeployKeyParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+	var keyID interface{}
+	if p.KeyID != "" {
+		keyID = p.KeyID
+	}
+	body := map[string]interface{}{
+		"build_settings": map[string]interface{}{"deploy_key_id": keyID},
+	}
+	if err := r.SetBodyParam(body); err != nil {
+		return err
+	}
+	return r.SetPathParam("site_id", p.SiteID)
+}
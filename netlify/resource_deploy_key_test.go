@@ -15,9 +15,9 @@ func TestAccDeployKey_basic(t *testing.T) {
 	resourceName := "netlify_deploy_key.test"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
-		CheckDestroy:      testAccCheckDeployKeyDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckDeployKeyDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDeployKeyConfig,
@@ -46,9 +46,9 @@ func TestAccDeployKey_disappears(t *testing.T) {
 	}
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
-		CheckDestroy:      testAccCheckDeployKeyDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckDeployKeyDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDeployKeyConfig,
@@ -62,6 +62,49 @@ func TestAccDeployKey_disappears(t *testing.T) {
 	})
 }
 
+func TestAccDeployKey_site(t *testing.T) {
+	var key models.DeployKey
+	var site, other models.Site
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckDeployKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDeployKeyConfig_site,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDeployKeyExists("netlify_deploy_key.test", &key),
+					testAccCheckSiteExists("netlify_site.test", &site),
+					testAccAssert("is the site deploy key", func() bool {
+						return site.BuildSettings != nil && site.BuildSettings.DeployKeyID == key.ID
+					}),
+					resource.TestCheckResourceAttrSet("netlify_deploy_key.test", "created_at"),
+				),
+			},
+			// Moving the key clears it on the first site, and keeps the branch
+			// deploys set up before it.
+			{
+				Config: testAccDeployKeyConfig_moveSite,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSiteExists("netlify_site.test", &site),
+					testAccAssert("cleared the first site's deploy key", func() bool {
+						return site.BuildSettings != nil && site.BuildSettings.DeployKeyID == ""
+					}),
+					testAccAssert("kept the branch deploys", func() bool {
+						mode, branches := branchDeploysFromRepoInfo(site.BuildSettings)
+						return mode == branchDeploysListed && len(branches) == 2
+					}),
+					testAccCheckSiteExists("netlify_site.other", &other),
+					testAccAssert("is the other site's deploy key", func() bool {
+						return other.BuildSettings != nil && other.BuildSettings.DeployKeyID == key.ID
+					}),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckDeployKeyExists(n string, key *models.DeployKey) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -101,7 +144,7 @@ func testAccCheckDeployKeyDestroy(s *terraform.State) error {
 		}
 
 		if err != nil {
-			if v, ok := err.(*operations.GetDeployKeyDefault); ok && v.Code() == 404 {
+			if isNotFound(err) {
 				return nil
 			}
 		}
@@ -113,3 +156,47 @@ func testAccCheckDeployKeyDestroy(s *terraform.State) error {
 }
 
 var testAccDeployKeyConfig = `resource "netlify_deploy_key" "test" {}`
+
+var testAccDeployKeyConfig_site = `
+resource "netlify_site" "test" {
+	repo {
+		provider = "github"
+		repo_path = "mitchellh/fogli"
+		repo_branch = "master"
+	}
+}
+
+resource "netlify_deploy_key" "test" {
+	site_id = netlify_site.test.id
+}
+`
+
+var testAccDeployKeyConfig_moveSite = `
+resource "netlify_site" "test" {
+	repo {
+		provider = "github"
+		repo_path = "mitchellh/fogli"
+		repo_branch = "master"
+	}
+}
+
+resource "netlify_site" "other" {
+	repo {
+		provider = "github"
+		repo_path = "mitchellh/fogli"
+		repo_branch = "master"
+	}
+}
+
+resource "netlify_site_branch_deploys" "test" {
+	site_id = netlify_site.test.id
+	mode = "listed"
+	branches = ["staging", "dev"]
+}
+
+resource "netlify_deploy_key" "test" {
+	site_id = netlify_site.other.id
+
+	depends_on = [netlify_site_branch_deploys.test]
+}
+`
@@ -1,6 +1,11 @@
 package netlify
 
 import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/netlify/open-api/v2/go/models"
 	"github.com/netlify/open-api/v2/go/plumbing/operations"
@@ -8,11 +13,17 @@ import (
 
 func resourceDnsRecord() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDnsRecordCreate,
-		Read:   resourceDnsRecordRead,
-		Delete: resourceDnsRecordDelete,
+		CreateContext: resourceDnsRecordCreate,
+		ReadContext:   resourceDnsRecordRead,
+		DeleteContext: resourceDnsRecordDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -43,8 +54,8 @@ func resourceDnsRecord() *schema.Resource {
 	}
 }
 
-func resourceDnsRecordCreate(d *schema.ResourceData, metaRaw interface{}) error {
-	params := operations.NewCreateDNSRecordParams()
+func resourceDnsRecordCreate(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	params := operations.NewCreateDNSRecordParamsWithContext(c)
 	params.ZoneID = d.Get("zone_id").(string)
 	params.DNSRecord = &models.DNSRecordCreate{
 		Hostname: d.Get("hostname").(string),
@@ -55,31 +66,30 @@ func resourceDnsRecordCreate(d *schema.ResourceData, metaRaw interface{}) error
 	meta := metaRaw.(*Meta)
 	resp, err := meta.Netlify.Operations.CreateDNSRecord(params, meta.AuthInfo)
 	if err != nil {
-		return err
+		return apiErrorDiags(err, cty.GetAttrPath("zone_id"))
 	}
 
 	d.SetId(resp.Payload.ID)
-	return resourceDnsRecordRead(d, metaRaw)
+	return resourceDnsRecordRead(c, d, metaRaw)
 }
 
-func resourceDnsRecordRead(d *schema.ResourceData, metaRaw interface{}) error {
+func resourceDnsRecordRead(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
 	meta := metaRaw.(*Meta)
-	params := operations.NewGetIndividualDNSRecordParams()
+	params := operations.NewGetIndividualDNSRecordParamsWithContext(c)
 	params.ZoneID = d.Get("zone_id").(string)
 	params.DNSRecordID = d.Id()
 	resp, err := meta.Netlify.Operations.GetIndividualDNSRecord(params, meta.AuthInfo)
 	if err != nil {
 		// If it is a 404 it was removed remotely
-		if v, ok := err.(*operations.GetIndividualDNSRecordDefault); ok && v.Code() == 404 {
+		if isNotFound(err) {
 			d.SetId("")
 			return nil
 		}
 
-		return err
+		return apiErrorDiags(err, nil)
 	}
 
 	zone := resp.Payload
-	d.Set("site_id", zone.SiteID)
 	d.Set("hostname", zone.Hostname)
 	d.Set("type", zone.Type)
 	d.Set("value", zone.Value)
@@ -87,11 +97,11 @@ func resourceDnsRecordRead(d *schema.ResourceData, metaRaw interface{}) error {
 	return nil
 }
 
-func resourceDnsRecordDelete(d *schema.ResourceData, metaRaw interface{}) error {
+func resourceDnsRecordDelete(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
 	meta := metaRaw.(*Meta)
-	params := operations.NewDeleteDNSRecordParams()
+	params := operations.NewDeleteDNSRecordParamsWithContext(c)
 	params.ZoneID = d.Get("zone_id").(string)
 	params.DNSRecordID = d.Id()
 	_, err := meta.Netlify.Operations.DeleteDNSRecord(params, meta.AuthInfo)
-	return err
+	return apiErrorDiags(err, nil)
 }
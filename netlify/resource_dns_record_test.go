@@ -0,0 +1,83 @@
+package netlify
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/netlify/open-api/v2/go/plumbing/operations"
+)
+
+func TestAccDNSRecord(t *testing.T) {
+	domain := fmt.Sprintf("tf-test-%s.com", RandStringBytes(6))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckDNSZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccDNSRecordConfig, domain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netlify_dns_zone.test", "domain", domain),
+					resource.TestCheckResourceAttrPair("netlify_dns_record.test", "zone_id", "netlify_dns_zone.test", "id"),
+					resource.TestCheckResourceAttr("netlify_dns_record.test", "value", "192.0.2.1"),
+				),
+			},
+			{
+				ResourceName:      "netlify_dns_zone.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "netlify_dns_zone.test",
+				ImportState:       true,
+				ImportStateId:     domain,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckDNSZoneDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "netlify_dns_zone" {
+			continue
+		}
+
+		meta := testAccProvider.Meta().(*Meta)
+		params := operations.NewGetDNSZoneParams()
+		params.ZoneID = rs.Primary.ID
+		resp, err := meta.Netlify.Operations.GetDNSZone(params, meta.AuthInfo)
+		if err == nil && resp.Payload != nil {
+			return fmt.Errorf("DNS zone still exists: %s", rs.Primary.ID)
+		}
+
+		if err != nil {
+			if isNotFound(err) {
+				return nil
+			}
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+var testAccDNSRecordConfig = `
+resource "netlify_site" "test" {}
+
+resource "netlify_dns_zone" "test" {
+	site_id = netlify_site.test.id
+	name = "%s"
+}
+
+resource "netlify_dns_record" "test" {
+	zone_id = netlify_dns_zone.test.id
+	hostname = "www"
+	type = "A"
+	value = "192.0.2.1"
+}
+`
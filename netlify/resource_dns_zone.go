@@ -1,6 +1,13 @@
 package netlify
 
 import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/netlify/open-api/v2/go/models"
 	"github.com/netlify/open-api/v2/go/plumbing/operations"
@@ -8,11 +15,17 @@ import (
 
 func resourceDnsZone() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDnsZoneCreate,
-		Read:   resourceDnsZoneRead,
-		Delete: resourceDnsZoneDelete,
+		CreateContext: resourceDnsZoneCreate,
+		ReadContext:   resourceDnsZoneRead,
+		DeleteContext: resourceDnsZoneDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: resourceDnsZoneImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -36,8 +49,8 @@ func resourceDnsZone() *schema.Resource {
 	}
 }
 
-func resourceDnsZoneCreate(d *schema.ResourceData, metaRaw interface{}) error {
-	params := operations.NewCreateDNSZoneParams()
+func resourceDnsZoneCreate(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	params := operations.NewCreateDNSZoneParamsWithContext(c)
 	params.DNSZoneParams = &models.DNSZoneSetup{
 		SiteID: d.Get("site_id").(string),
 		Name:   d.Get("name").(string),
@@ -46,26 +59,26 @@ func resourceDnsZoneCreate(d *schema.ResourceData, metaRaw interface{}) error {
 	meta := metaRaw.(*Meta)
 	resp, err := meta.Netlify.Operations.CreateDNSZone(params, meta.AuthInfo)
 	if err != nil {
-		return err
+		return apiErrorDiags(err, cty.GetAttrPath("site_id"))
 	}
 
 	d.SetId(resp.Payload.ID)
-	return resourceDnsZoneRead(d, metaRaw)
+	return resourceDnsZoneRead(c, d, metaRaw)
 }
 
-func resourceDnsZoneRead(d *schema.ResourceData, metaRaw interface{}) error {
+func resourceDnsZoneRead(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
 	meta := metaRaw.(*Meta)
-	params := operations.NewGetDNSZoneParams()
+	params := operations.NewGetDNSZoneParamsWithContext(c)
 	params.ZoneID = d.Id()
 	resp, err := meta.Netlify.Operations.GetDNSZone(params, meta.AuthInfo)
 	if err != nil {
 		// If it is a 404 it was removed remotely
-		if v, ok := err.(*operations.GetDNSZoneDefault); ok && v.Code() == 404 {
+		if isNotFound(err) {
 			d.SetId("")
 			return nil
 		}
 
-		return err
+		return apiErrorDiags(err, nil)
 	}
 
 	zone := resp.Payload
@@ -76,10 +89,33 @@ func resourceDnsZoneRead(d *schema.ResourceData, metaRaw interface{}) error {
 	return nil
 }
 
-func resourceDnsZoneDelete(d *schema.ResourceData, metaRaw interface{}) error {
+func resourceDnsZoneDelete(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
 	meta := metaRaw.(*Meta)
-	params := operations.NewDeleteDNSZoneParams()
+	params := operations.NewDeleteDNSZoneParamsWithContext(c)
 	params.ZoneID = d.Id()
 	_, err := meta.Netlify.Operations.DeleteDNSZone(params, meta.AuthInfo)
-	return err
+	return apiErrorDiags(err, nil)
+}
+
+// Imports a DNS zone by its ID, or by its domain, which unlike the ID
+// contains a dot.
+func resourceDnsZoneImport(c context.Context, d *schema.ResourceData, metaRaw interface{}) ([]*schema.ResourceData, error) {
+	if !strings.Contains(d.Id(), ".") {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	meta := metaRaw.(*Meta)
+	params := operations.NewGetDNSZonesParamsWithContext(c)
+	resp, err := meta.Netlify.Operations.GetDNSZones(params, meta.AuthInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, zone := range resp.Payload {
+		if zone.Name == d.Id() {
+			d.SetId(zone.ID)
+			return []*schema.ResourceData{d}, nil
+		}
+	}
+	return nil, fmt.Errorf("No DNS zone has the domain %q", d.Id())
 }
@@ -1,192 +1,346 @@
 package netlify
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/netlify/open-api/v2/go/models"
 	"github.com/netlify/open-api/v2/go/plumbing/operations"
 )
 
-func resourceEnvVar() *schema.Resource {
-	return &schema.Resource{
-		Create: resourceEnvVarCreate,
-		Read:   resourceEnvVarRead,
-		Update: resourceEnvVarUpdate,
-		Delete: resourceEnvVarDelete,
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
-		Schema: map[string]*schema.Schema{
-			"account_id": {
-				Type:        schema.TypeString,
-				Description: "The account ID / slug to create the environment variable for.",
-				Required:    true,
-				ForceNew:    true,
+var (
+	_ resource.ResourceWithConfigure    = &envVarResource{}
+	_ resource.ResourceWithImportState  = &envVarResource{}
+	_ resource.ResourceWithUpgradeState = &envVarResource{}
+)
+
+// envVarResource is implemented with the plugin framework. The SDK version of
+// the schema (version 0) stored an unset site_id as an empty string.
+type envVarResource struct {
+	meta *Meta
+}
+
+func newEnvVarResource() resource.Resource {
+	return &envVarResource{}
+}
+
+type envVarModel struct {
+	ID        types.String   `tfsdk:"id"`
+	AccountID types.String   `tfsdk:"account_id"`
+	SiteID    types.String   `tfsdk:"site_id"`
+	Key       types.String   `tfsdk:"key"`
+	Scopes    types.Set      `tfsdk:"scopes"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+}
+
+// The scopes of an environment variable that is not given any.
+var envVarAllScopes = []string{"builds", "functions", "post_processing", "runtime"}
+
+func (r *envVarResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_environment_variable"
+}
+
+func (r *envVarResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: 1,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+
+			"account_id": schema.StringAttribute{
+				MarkdownDescription: "The account ID / slug to create the environment variable for. Defaults to the provider's `default_account_slug`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 
-			"site_id": {
-				Type:        schema.TypeString,
-				Description: "If provided, creates the environment variable on the site level, not the account level",
-				Optional:    true,
-				ForceNew:    true,
+			"site_id": schema.StringAttribute{
+				MarkdownDescription: "If provided, creates the environment variable on the site level, not the account level",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 
-			"key": {
-				Type:        schema.TypeString,
-				Description: "The name of the environment variable (case-sensitive).",
-				Required:    true,
+			"key": schema.StringAttribute{
+				MarkdownDescription: "The name of the environment variable (case-sensitive).",
+				Required:            true,
 			},
 
-			"scopes": {
-				Type:        schema.TypeSet,
-				Description: "The scopes that this environment variable is set to (Pro plans and above)",
-				Optional:    true,
-				Computed:    true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
+			"scopes": schema.SetAttribute{
+				MarkdownDescription: "The scopes that this environment variable is set to (Pro plans and above)",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
 				},
 			},
 		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
 	}
 }
 
-func resourceEnvVarCreate(d *schema.ResourceData, metaRaw interface{}) error {
-	meta := metaRaw.(*Meta)
+func (r *envVarResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.meta = frameworkMeta(req.ProviderData, &resp.Diagnostics)
+}
+
+func (r *envVarResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan envVarModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
 	// initialize creation parameters with default account ID, or supplied.
-	params := operations.NewCreateEnvVarsParams()
-	key := d.Get("key").(string)
-	site_id := d.Get("site_id").(string)
-	params.AccountID = d.Get("account_id").(string)
-	params.SiteID = &site_id
+	params := operations.NewCreateEnvVarsParamsWithContext(ctx)
+	params.AccountID = plan.AccountID.ValueString()
+	if params.AccountID == "" {
+		params.AccountID = r.meta.DefaultAccountSlug
+	}
+	if params.AccountID == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("account_id"), "Missing account_id", "account_id must be set, as the provider has no default_account_slug")
+		return
+	}
+	siteID := plan.SiteID.ValueString()
+	params.SiteID = &siteID
 
 	// build env vars create object
-	env_vars := models.CreateEnvVarsParamsBodyItems{}
-	env_vars.Key = key
-
-	// set the scope of the environment variable
-	if scopesI, ok := d.GetOk("scopes"); ok {
-		scopesList := scopesI.(*schema.Set).List()
-		if len(scopesList) > 0 {
-			scopes := []string{}
-			for _, scope := range scopesList {
-				scopes = append(scopes, scope.(string))
-			}
-			env_vars.Scopes = scopes
-		}
-	}
-	// if no scope found, set to all scopes
-	if env_vars.Scopes == nil {
-		env_vars.Scopes = []string{"builds", "functions", "post_processing", "runtime"}
+	envVar := models.CreateEnvVarsParamsBodyItems{Key: plan.Key.ValueString()}
+	envVar.Scopes, diags = plan.scopes(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// we need a placeholder value to be able to create the key
-	env_vars.Values = []*models.EnvVarValue{
+	envVar.Values = []*models.EnvVarValue{
 		{
 			Context: "all",
 			Value:   "",
 		},
 	}
-	params.EnvVars = []*models.CreateEnvVarsParamsBodyItems{&env_vars}
+	params.EnvVars = []*models.CreateEnvVarsParamsBodyItems{&envVar}
 
 	// perform the operation
-	_, err := meta.Netlify.Operations.CreateEnvVars(params, meta.AuthInfo)
-	if err != nil {
-		return err
+	if _, err := r.meta.Netlify.Operations.CreateEnvVars(params, r.meta.AuthInfo); err != nil {
+		addAPIError(&resp.Diagnostics, err, path.Root("account_id"))
+		return
 	}
 
 	// set the resource id from account ID, site ID, and key
-	d.SetId(getResourceIdFromEnvVarInfo(params.AccountID, params.SiteID, key))
-	return resourceEnvVarRead(d, metaRaw)
+	plan.ID = types.StringValue(getResourceIdFromEnvVarInfo(params.AccountID, params.SiteID, envVar.Key))
+	resp.Diagnostics.Append(r.read(ctx, &plan, &resp.State)...)
 }
 
-func resourceEnvVarRead(d *schema.ResourceData, metaRaw interface{}) error {
-	meta := metaRaw.(*Meta)
-	params := operations.NewGetEnvVarParams()
-	// get account ID, site ID, and Key from resource ID
-	account_id, site_id, key := getEnvVarInfoFromResourceId(d.Id())
-	params.AccountID = account_id
-	params.SiteID = site_id
-	params.Key = key
+func (r *envVarResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state envVarModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	resp, err := meta.Netlify.Operations.GetEnvVar(params, meta.AuthInfo)
-	if err != nil {
-		// If it is a 404, it was removed remotely
-		if v, ok := err.(*operations.GetEnvVarDefault); ok && v.Code() == 404 {
-			d.SetId("")
-			return nil
-		}
+	readTimeout, diags := state.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	envVar := resp.Payload
-	d.Set("key", envVar.Key)
-	d.Set("scopes", envVar.Scopes)
-	return err
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(r.read(ctx, &state, &resp.State)...)
 }
 
-func resourceEnvVarUpdate(d *schema.ResourceData, metaRaw interface{}) error {
-	meta := metaRaw.(*Meta)
-	params := operations.NewUpdateEnvVarParams()
+func (r *envVarResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state envVarModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// get previous account ID, site ID, and Key from resource ID
-	account_id, site_id, key := getEnvVarInfoFromResourceId(d.Id())
-	params.AccountID = account_id
-	params.SiteID = site_id
-	params.Key = key
+	accountID, siteID, key := getEnvVarInfoFromResourceId(state.ID.ValueString())
 
 	// build env vars update object
-	env_vars := models.UpdateEnvVarParamsBody{}
-	env_vars.Key = d.Get("key").(string)
-
-	// set the scope of the environment variable
-	if scopesI, ok := d.GetOk("scopes"); ok {
-		scopesList := scopesI.(*schema.Set).List()
-		if len(scopesList) > 0 {
-			scopes := []string{}
-			for _, scope := range scopesList {
-				scopes = append(scopes, scope.(string))
-			}
-			env_vars.Scopes = scopes
-		}
-	}
-	if env_vars.Scopes == nil {
-		env_vars.Scopes = []string{"builds", "functions", "post_processing", "runtime"}
+	envVar := models.UpdateEnvVarParamsBody{Key: plan.Key.ValueString()}
+	envVar.Scopes, diags = plan.scopes(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// query for previous values, which we need to preserve
-	params_get := operations.NewGetEnvVarParams()
-	params_get.AccountID = account_id
-	params_get.SiteID = site_id
-	params_get.Key = key
-	resp_get, err_get := meta.Netlify.Operations.GetEnvVar(params_get, meta.AuthInfo)
-	if err_get != nil {
-		return err_get
+	getParams := operations.NewGetEnvVarParamsWithContext(ctx)
+	getParams.AccountID = accountID
+	getParams.SiteID = siteID
+	getParams.Key = key
+	getResp, err := r.meta.Netlify.Operations.GetEnvVar(getParams, r.meta.AuthInfo)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, err, path.Empty())
+		return
 	}
-	env_vars.Values = resp_get.Payload.Values
-	params.EnvVar = &env_vars
+	envVar.Values = getResp.Payload.Values
 
 	// perform the operation
-	resp, err := meta.Netlify.Operations.UpdateEnvVar(params, meta.AuthInfo)
+	params := operations.NewUpdateEnvVarParamsWithContext(ctx)
+	params.AccountID = accountID
+	params.SiteID = siteID
+	params.Key = key
+	params.EnvVar = &envVar
+	updateResp, err := r.meta.Netlify.Operations.UpdateEnvVar(params, r.meta.AuthInfo)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, err, path.Empty())
+		return
+	}
+
+	// set the resource id (which may have changed) from account id, site id, and key
+	plan.ID = types.StringValue(getResourceIdFromEnvVarInfo(accountID, siteID, updateResp.Payload.Key))
+	resp.Diagnostics.Append(r.read(ctx, &plan, &resp.State)...)
+}
+
+func (r *envVarResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state envVarModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	params := operations.NewDeleteEnvVarParamsWithContext(ctx)
+	params.AccountID, params.SiteID, params.Key = getEnvVarInfoFromResourceId(state.ID.ValueString())
+	if _, err := r.meta.Netlify.Operations.DeleteEnvVar(params, r.meta.AuthInfo); err != nil {
+		addAPIError(&resp.Diagnostics, err, path.Empty())
+	}
+}
+
+func (r *envVarResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if len(strings.Split(req.ID, "/")) < 3 {
+		resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("Expected <key>/<account_id>/<site_id>, with an empty site_id for account environment variables, got %q", req.ID))
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Reads the environment variable into the model and saves it, removing the
+// resource if it was removed remotely.
+func (r *envVarResource) read(ctx context.Context, m *envVarModel, state stateSetter) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	// get account ID, site ID, and Key from resource ID
+	params := operations.NewGetEnvVarParamsWithContext(ctx)
+	params.AccountID, params.SiteID, params.Key = getEnvVarInfoFromResourceId(m.ID.ValueString())
+	resp, err := r.meta.Netlify.Operations.GetEnvVar(params, r.meta.AuthInfo)
 	if err != nil {
-		return err
+		// If it is a 404, it was removed remotely
+		if isNotFound(err) {
+			state.RemoveResource(ctx)
+			return diags
+		}
+
+		addAPIError(&diags, err, path.Empty())
+		return diags
 	}
 
 	envVar := resp.Payload
+	m.AccountID = types.StringValue(params.AccountID)
+	m.SiteID = types.StringNull()
+	if params.SiteID != nil {
+		m.SiteID = nullableString(*params.SiteID)
+	}
+	m.Key = types.StringValue(envVar.Key)
+	m.Scopes, diags = types.SetValueFrom(ctx, types.StringType, envVar.Scopes)
+	if diags.HasError() {
+		return diags
+	}
 
-	// set the resource id (which may have changed) from account id, site id, and key
-	d.SetId(getResourceIdFromEnvVarInfo(params.AccountID, params.SiteID, envVar.Key))
-	return resourceEnvVarRead(d, metaRaw)
-}
-
-func resourceEnvVarDelete(d *schema.ResourceData, metaRaw interface{}) error {
-	meta := metaRaw.(*Meta)
-	params := operations.NewDeleteEnvVarParams()
-	params.AccountID = d.Get("account_id").(string)
-	site_id := d.Get("site_id").(string)
-	params.SiteID = &site_id
-	params.Key = d.Get("key").(string)
-	_, err := meta.Netlify.Operations.DeleteEnvVar(params, meta.AuthInfo)
-	return err
+	return state.Set(ctx, m)
+}
+
+// Returns the configured scopes, or all scopes if none are configured.
+func (m *envVarModel) scopes(ctx context.Context) ([]string, diag.Diagnostics) {
+	var scopes []string
+	if m.Scopes.IsNull() || m.Scopes.IsUnknown() {
+		return envVarAllScopes, nil
+	}
+
+	diags := m.Scopes.ElementsAs(ctx, &scopes, false)
+	if len(scopes) == 0 {
+		return envVarAllScopes, diags
+	}
+	return scopes, diags
+}
+
+func (r *envVarResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":         schema.StringAttribute{Optional: true, Computed: true},
+					"account_id": schema.StringAttribute{Optional: true, Computed: true},
+					"site_id":    schema.StringAttribute{Optional: true},
+					"key":        schema.StringAttribute{Required: true},
+					"scopes":     schema.SetAttribute{ElementType: types.StringType, Optional: true, Computed: true},
+				},
+				Blocks: map[string]schema.Block{
+					"timeouts": timeouts.BlockAll(ctx),
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior envVarModel
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				prior.SiteID = nullableString(prior.SiteID.ValueString())
+				resp.Diagnostics.Append(resp.State.Set(ctx, prior)...)
+			},
+		},
+	}
 }
 
 func getEnvVarInfoFromResourceId(id string) (account_id string, site_id *string, key string) {
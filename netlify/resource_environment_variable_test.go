@@ -2,14 +2,34 @@ package netlify
 
 import (
 	"fmt"
+	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/netlify/open-api/v2/go/models"
 	"github.com/netlify/open-api/v2/go/plumbing/operations"
 )
 
+func TestEnvVarResource_upgradeStateV0(t *testing.T) {
+	attributes := testUpgradeResourceState(t, "netlify_environment_variable", 0, `{
+		"id": "KEY/account//",
+		"account_id": "account",
+		"site_id": "",
+		"key": "KEY",
+		"scopes": ["builds"],
+		"timeouts": null
+	}`)
+
+	if !attributes["site_id"].IsNull() {
+		t.Errorf("expected site_id to be null, got %s", attributes["site_id"])
+	}
+	if !attributes["id"].Equal(tftypes.NewValue(tftypes.String, "KEY/account//")) {
+		t.Errorf("unexpected id %s", attributes["id"])
+	}
+}
+
 func TestAccEnvVar_basic(t *testing.T) {
 	var site models.Site
 	var envVar1 models.EnvVar
@@ -17,9 +37,9 @@ func TestAccEnvVar_basic(t *testing.T) {
 	resourceName := "netlify_site.test"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
-		CheckDestroy:      testAccCheckSiteAndEnvVarsDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckSiteAndEnvVarsDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccEnvVarSiteSpecificConfig,
@@ -33,6 +53,30 @@ func TestAccEnvVar_basic(t *testing.T) {
 	})
 }
 
+func TestAccEnvVar_defaultAccount(t *testing.T) {
+	account := os.Getenv("NETLIFY_TEST_ACCOUNT_SLUG")
+	if account == "" {
+		t.Skip("NETLIFY_TEST_ACCOUNT_SLUG must be set to test the default account")
+	}
+
+	var envVar models.EnvVar
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckSiteAndEnvVarsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccEnvVarDefaultAccountConfig, account),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckEnvVarExists("var1", "var1", &envVar),
+					resource.TestCheckResourceAttr("netlify_site.test", "account_slug", account),
+					resource.TestCheckResourceAttr("netlify_environment_variable.var1", "account_id", account),
+				),
+			},
+		},
+	})
+}
+
 func TestAccEnvVar_disappears(t *testing.T) {
 	var site models.Site
 	var envVar models.EnvVar
@@ -49,9 +93,9 @@ func TestAccEnvVar_disappears(t *testing.T) {
 	}
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
-		CheckDestroy:      testAccCheckSiteAndEnvVarsDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckSiteAndEnvVarsDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccEnvVarSiteSpecificConfig,
@@ -71,9 +115,9 @@ func TestAccEnvVar_updatesKey(t *testing.T) {
 	var envVar models.EnvVar
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
-		CheckDestroy:      testAccCheckSiteAndEnvVarsDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckSiteAndEnvVarsDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccEnvVarSiteSpecificConfig,
@@ -140,7 +184,7 @@ func testAccCheckSiteAndEnvVarsDestroy(s *terraform.State) error {
 		}
 
 		if err != nil {
-			if v, ok := err.(*operations.GetEnvVarDefault); ok && v.Code() == 404 {
+			if isNotFound(err) {
 				return nil
 			}
 		}
@@ -179,3 +223,16 @@ resource "netlify_environment_variable" "var2" {
 	key	= "var2"
 }
 `
+
+var testAccEnvVarDefaultAccountConfig = `
+provider "netlify" {
+	default_account_slug = "%s"
+}
+
+resource "netlify_site" "test" {}
+
+resource "netlify_environment_variable" "var1" {
+	site_id = netlify_site.test.id
+	key	= "var1"
+}
+`
@@ -2,8 +2,9 @@ package netlify
 
 import (
 	"context"
+	"errors"
+	"time"
 
-	"github.com/go-openapi/runtime"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -20,6 +21,14 @@ func resourceEnvVarValue() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"environment_variable_id": {
 				Type:        schema.TypeString,
@@ -61,7 +70,7 @@ func resourceEnvVarValueCreateOrUpdate(c context.Context, d *schema.ResourceData
 	meta := metaRaw.(*Meta)
 
 	// initialize creation parameters
-	params := operations.NewSetEnvVarValueParams()
+	params := operations.NewSetEnvVarValueParamsWithContext(c)
 	account_id, site_id, key := getEnvVarInfoFromResourceId(d.Get("environment_variable_id").(string))
 	params.AccountID = account_id
 	params.SiteID = site_id
@@ -75,8 +84,8 @@ func resourceEnvVarValueCreateOrUpdate(c context.Context, d *schema.ResourceData
 	_, err := meta.Netlify.Operations.SetEnvVarValue(params, meta.AuthInfo)
 	if err != nil {
 		// 200 status codes are generally okay
-		if v, ok := err.(*runtime.APIError); !ok || v.Code != 200 {
-			return diag.FromErr(err)
+		if apiErrorStatus(err) != 200 {
+			return apiErrorDiags(err, cty.GetAttrPath("environment_variable_id"))
 		}
 	}
 
@@ -88,7 +97,7 @@ func resourceEnvVarValueRead(c context.Context, d *schema.ResourceData, metaRaw
 	meta := metaRaw.(*Meta)
 
 	// initialize read parameters for top-level key
-	params := operations.NewGetEnvVarParams()
+	params := operations.NewGetEnvVarParamsWithContext(c)
 	account_id, site_id, key := getEnvVarInfoFromResourceId(d.Get("environment_variable_id").(string))
 	params.AccountID = account_id
 	params.SiteID = site_id
@@ -98,11 +107,11 @@ func resourceEnvVarValueRead(c context.Context, d *schema.ResourceData, metaRaw
 	resp, err := meta.Netlify.Operations.GetEnvVar(params, meta.AuthInfo)
 	if err != nil {
 		// If it is a 404 it was removed remotely
-		if v, ok := err.(*operations.GetEnvVarDefault); ok && v.Code() == 404 {
+		if isNotFound(err) {
 			d.SetId("")
 			return nil
 		}
-		return diag.FromErr(err)
+		return apiErrorDiags(err, nil)
 	}
 
 	// find the environment variable value
@@ -128,7 +137,7 @@ func resourceEnvVarValueDelete(c context.Context, d *schema.ResourceData, metaRa
 	meta := metaRaw.(*Meta)
 
 	// initialize creation parameters for setting it to no-value
-	params := operations.NewSetEnvVarValueParams()
+	params := operations.NewSetEnvVarValueParamsWithContext(c)
 	account_id, site_id, key := getEnvVarInfoFromResourceId(d.Get("environment_variable_id").(string))
 	params.AccountID = account_id
 	params.SiteID = site_id
@@ -142,8 +151,9 @@ func resourceEnvVarValueDelete(c context.Context, d *schema.ResourceData, metaRa
 	_, err := meta.Netlify.Operations.SetEnvVarValue(params, meta.AuthInfo)
 	if err != nil {
 		// default response is OK if it's just the default
-		if v, ok := err.(*operations.SetEnvVarValueDefault); !ok && v == nil {
-			return diag.FromErr(err)
+		var v *operations.SetEnvVarValueDefault
+		if !errors.As(err, &v) {
+			return apiErrorDiags(err, nil)
 		}
 	}
 	return nil
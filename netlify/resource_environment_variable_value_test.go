@@ -13,9 +13,9 @@ import (
 
 func TestAccEnvVarValue_basic(t *testing.T) {
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
-		CheckDestroy:      testAccCheckSiteAndEnvVarsDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckSiteAndEnvVarsDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccEnvVarValueConfig,
@@ -46,7 +46,7 @@ func TestAccEnvVarValue_disappears(t *testing.T) {
 		_, err := meta.Netlify.Operations.GetEnvVar(params, meta.AuthInfo)
 		if err != nil {
 			// If it is a 404 it was removed remotely
-			if v, ok := err.(*operations.GetEnvVarDefault); ok && v.Code() == 404 {
+			if isNotFound(err) {
 				return nil
 			}
 			return err
@@ -55,9 +55,9 @@ func TestAccEnvVarValue_disappears(t *testing.T) {
 	}
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
-		CheckDestroy:      testAccCheckSiteDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckSiteDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccSiteConfig,
@@ -74,9 +74,9 @@ func TestAccEnvVarValue_disappears(t *testing.T) {
 
 func TestAccEnvVarValue_changesValue(t *testing.T) {
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
-		CheckDestroy:      testAccCheckSiteAndEnvVarsDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckSiteAndEnvVarsDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccEnvVarValueConfig,
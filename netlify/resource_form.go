@@ -0,0 +1,318 @@
+package netlify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/netlify/open-api/v2/go/models"
+	"github.com/netlify/open-api/v2/go/plumbing/operations"
+)
+
+func resourceForm() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages a form that Netlify has detected in a deploy of a site. Forms cannot be created through the API, so the form must already exist; destroying this resource deletes the form and its submissions.",
+		CreateContext: resourceFormCreate,
+		ReadContext:   resourceFormRead,
+		UpdateContext: resourceFormUpdate,
+		DeleteContext: resourceFormDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"site_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the site the form belongs to.",
+				Required:    true,
+				ForceNew:    true,
+			},
+
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the form, as set by its `name` attribute.",
+				Required:    true,
+				ForceNew:    true,
+			},
+
+			"submission_notification": {
+				Type:        schema.TypeList,
+				Description: "A hook called for every submission of this form.",
+				MaxItems:    1,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:        schema.TypeString,
+							Description: "The hook type, e.g. `email`, `slack` or `url`.",
+							Required:    true,
+						},
+
+						"data": {
+							Type:        schema.TypeMap,
+							Description: "The hook data, e.g. `email` or `url`.",
+							Required:    true,
+							Sensitive:   true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+
+						"hook_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"paths": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"fields": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"submission_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceFormCreate(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	meta := metaRaw.(*Meta)
+	siteID := d.Get("site_id").(string)
+	name := d.Get("name").(string)
+	forms, err := meta.Netlify.ListFormsBySiteId(meta.porcelainContext(c), siteID)
+	if err != nil {
+		return apiErrorDiags(err, cty.GetAttrPath("site_id"))
+	}
+
+	var formID string
+	for _, form := range forms {
+		if form.Name == name {
+			formID = form.ID
+			break
+		}
+	}
+	if formID == "" {
+		return diag.Errorf("Form %q not found on site %s. Forms are created by deploying a page containing the form.", name, siteID)
+	}
+
+	// The form is only saved once its notification exists. Were it saved
+	// before, a failed notification would taint it, and replacing it would
+	// delete the form and its submissions.
+	if err := resourceForm_createNotification(c, meta, d, formID); err != nil {
+		return apiErrorDiags(err, cty.GetAttrPath("submission_notification"))
+	}
+
+	d.SetId(formID)
+	return resourceFormRead(c, d, metaRaw)
+}
+
+func resourceFormRead(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	meta := metaRaw.(*Meta)
+	forms, err := meta.Netlify.ListFormsBySiteId(meta.porcelainContext(c), d.Get("site_id").(string))
+	if err != nil {
+		return apiErrorDiags(err, nil)
+	}
+
+	var form *models.Form
+	for _, f := range forms {
+		if f.ID == d.Id() {
+			form = f
+			break
+		}
+	}
+	if form == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("site_id", form.SiteID)
+	d.Set("name", form.Name)
+	d.Set("paths", form.Paths)
+	d.Set("fields", formFieldNames(form))
+	d.Set("submission_count", int(form.SubmissionCount))
+
+	if v, ok := d.GetOk("submission_notification"); ok {
+		notification := v.([]interface{})[0].(map[string]interface{})
+		params := operations.NewGetHookParamsWithContext(c)
+		params.HookID = notification["hook_id"].(string)
+		resp, err := meta.Netlify.Operations.GetHook(params, meta.AuthInfo)
+		if err != nil {
+			// The hook was removed remotely, so it will be created again.
+			if isNotFound(err) {
+				d.Set("submission_notification", nil)
+				return nil
+			}
+
+			return apiErrorDiags(err, nil)
+		}
+
+		hook := resp.Payload
+		data := map[string]interface{}{}
+		if m, ok := hook.Data.(map[string]interface{}); ok {
+			for k, v := range m {
+				data[k] = fmt.Sprint(v)
+			}
+		}
+		d.Set("submission_notification", []interface{}{
+			map[string]interface{}{
+				"type":    hook.Type,
+				"data":    data,
+				"hook_id": hook.ID,
+			},
+		})
+	}
+
+	return nil
+}
+
+func resourceFormUpdate(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	meta := metaRaw.(*Meta)
+
+	// The hook is replaced rather than updated, as updating it would not
+	// keep it bound to the form.
+	if d.HasChange("submission_notification") {
+		old, _ := d.GetChange("submission_notification")
+		if err := resourceForm_deleteNotification(c, meta, old.([]interface{})); err != nil {
+			return apiErrorDiags(err, nil)
+		}
+
+		if err := resourceForm_createNotification(c, meta, d, d.Id()); err != nil {
+			return apiErrorDiags(err, cty.GetAttrPath("submission_notification"))
+		}
+	}
+
+	return resourceFormRead(c, d, metaRaw)
+}
+
+func resourceFormDelete(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	meta := metaRaw.(*Meta)
+	if err := resourceForm_deleteNotification(c, meta, d.Get("submission_notification").([]interface{})); err != nil {
+		return apiErrorDiags(err, nil)
+	}
+
+	params := operations.NewDeleteSiteFormParamsWithContext(c)
+	params.SiteID = d.Get("site_id").(string)
+	params.FormID = d.Id()
+	_, err := meta.Netlify.Operations.DeleteSiteForm(params, meta.AuthInfo)
+	return apiErrorDiags(err, nil)
+}
+
+// Creates the configured submission notification, if any, and records its ID.
+func resourceForm_createNotification(c context.Context, meta *Meta, d *schema.ResourceData, formID string) error {
+	v, ok := d.GetOk("submission_notification")
+	if !ok {
+		return nil
+	}
+	notification := v.([]interface{})[0].(map[string]interface{})
+
+	params := &formHookParams{
+		SiteID: d.Get("site_id").(string),
+		Hook: formHook{
+			FormID: formID,
+			Type:   notification["type"].(string),
+			Event:  "submission_created",
+			Data:   notification["data"].(map[string]interface{}),
+		},
+	}
+	result, err := meta.Netlify.Transport.Submit(&runtime.ClientOperation{
+		ID:                 "createHookBySiteId",
+		Method:             "POST",
+		PathPattern:        "/hooks",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params:             params,
+		Reader:             &operations.CreateHookBySiteIDReader{},
+		AuthInfo:           meta.AuthInfo,
+		Context:            c,
+	})
+	if err != nil {
+		return err
+	}
+
+	created, ok := result.(*operations.CreateHookBySiteIDCreated)
+	if !ok {
+		return fmt.Errorf("Unexpected response creating form notification: %v", result)
+	}
+
+	notification["hook_id"] = created.Payload.ID
+	return d.Set("submission_notification", []interface{}{notification})
+}
+
+// Deletes the hook of a previously created submission notification.
+func resourceForm_deleteNotification(c context.Context, meta *Meta, notifications []interface{}) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	hookID := notifications[0].(map[string]interface{})["hook_id"].(string)
+	if hookID == "" {
+		return nil
+	}
+
+	params := operations.NewDeleteHookParamsWithContext(c)
+	params.HookID = hookID
+	_, err := meta.Netlify.Operations.DeleteHook(params, meta.AuthInfo)
+	if isNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// A hook bound to a form. The Hook model has no form ID, so form hooks are
+// created with this body instead.
+type formHook struct {
+	FormID string                 `json:"form_id"`
+	Type   string                 `json:"type"`
+	Event  string                 `json:"event"`
+	Data   map[string]interface{} `json:"data"`
+}
+
+type formHookParams struct {
+	SiteID string
+	Hook   formHook
+}
+
+func (p *formHookParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+	if err := r.SetBodyParam(p.Hook); err != nil {
+		return err
+	}
+	return r.SetQueryParam("site_id", p.SiteID)
+}
+
+// Returns the names of the fields of a form.
+func formFieldNames(form *models.Form) []string {
+	names := []string{}
+	for _, f := range form.Fields {
+		if m, ok := f.(map[string]interface{}); ok {
+			if name, ok := m["name"].(string); ok {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
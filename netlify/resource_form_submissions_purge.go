@@ -0,0 +1,129 @@
+package netlify
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/netlify/open-api/v2/go/plumbing/operations"
+)
+
+func resourceFormSubmissionsPurge() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Deletes the form submissions matching the given filters when created. Any change to the arguments, including `triggers`, deletes matching submissions again. Destroying this resource does nothing.",
+		CreateContext: resourceFormSubmissionsPurgeCreate,
+		ReadContext:   schema.NoopContext,
+		DeleteContext: resourceFormSubmissionsPurgeDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"site_id": {
+				Type:         schema.TypeString,
+				Description:  "The ID of the site to delete submissions of all forms for. Required if form_id is not specified.",
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"site_id", "form_id"},
+			},
+
+			"form_id": {
+				Type:         schema.TypeString,
+				Description:  "The ID of the form to delete submissions for. Required if site_id is not specified.",
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"site_id", "form_id"},
+			},
+
+			"created_after": {
+				Type:         schema.TypeString,
+				Description:  "Only delete submissions created after this RFC 3339 timestamp.",
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+
+			"created_before": {
+				Type:          schema.TypeString,
+				Description:   "Only delete submissions created before this RFC 3339 timestamp.",
+				Optional:      true,
+				ForceNew:      true,
+				ValidateFunc:  validation.IsRFC3339Time,
+				ConflictsWith: []string{"older_than"},
+			},
+
+			"older_than": {
+				Type:          schema.TypeString,
+				Description:   "Only delete submissions older than this duration at the time of apply, e.g. `720h`.",
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"created_before"},
+				ValidateFunc:  validateDuration,
+			},
+
+			"triggers": {
+				Type:        schema.TypeMap,
+				Description: "Arbitrary map of values that, when changed, will delete matching submissions again.",
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"deleted_count": {
+				Type:        schema.TypeInt,
+				Description: "The number of submissions that were deleted.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceFormSubmissionsPurgeCreate(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	meta := metaRaw.(*Meta)
+	filter, err := submissionFilterFromResourceData(d)
+	if err != nil {
+		return apiErrorDiags(err, nil)
+	}
+	if v, ok := d.GetOk("older_than"); ok {
+		age, _ := time.ParseDuration(v.(string))
+		filter.before = time.Now().Add(-age)
+	}
+
+	submissions, err := listSubmissions(c, meta, d.Get("site_id").(string), d.Get("form_id").(string), filter)
+	if err != nil {
+		return apiErrorDiags(err, nil)
+	}
+
+	deleted := 0
+	for _, s := range submissions {
+		params := operations.NewDeleteSubmissionParamsWithContext(c)
+		params.SubmissionID = s.ID
+		_, err := meta.Netlify.Operations.DeleteSubmission(params, meta.AuthInfo)
+		if err != nil {
+			// It may have been deleted concurrently.
+			if isNotFound(err) {
+				continue
+			}
+
+			return diag.Errorf("Error deleting submission %s after deleting %d submissions: %s", s.ID, deleted, err)
+		}
+		deleted++
+	}
+
+	d.SetId(resource.UniqueId())
+	d.Set("deleted_count", deleted)
+	return nil
+}
+
+// Purged submissions are gone for good, there is nothing to restore.
+func resourceFormSubmissionsPurgeDelete(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
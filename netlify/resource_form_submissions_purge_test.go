@@ -0,0 +1,34 @@
+package netlify
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccFormSubmissionsPurge(t *testing.T) {
+	testAccSkipFake(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckSiteDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFormSubmissionsPurgeConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netlify_form_submissions_purge.test", "deleted_count", "0"),
+				),
+			},
+		},
+	})
+}
+
+var testAccFormSubmissionsPurgeConfig = `
+resource "netlify_site" "test" {}
+
+resource "netlify_form_submissions_purge" "test" {
+	site_id = netlify_site.test.id
+	older_than = "720h"
+}
+`
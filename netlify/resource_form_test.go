@@ -0,0 +1,82 @@
+package netlify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestFormCreate_notificationFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/sites/site/forms":
+			w.Write([]byte(`[{"id": "form", "site_id": "site", "name": "contact"}]`))
+		case r.Method == "POST" && r.URL.Path == "/hooks":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"code": 422, "message": "Invalid hook data"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	config := Config{Token: "token", BaseURL: server.URL}
+	meta, err := config.Client(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := resourceForm()
+	d := r.TestResourceData()
+	d.Set("site_id", "site")
+	d.Set("name", "contact")
+	d.Set("submission_notification", []interface{}{map[string]interface{}{
+		"type": "email",
+		"data": map[string]interface{}{"email": "test@example.com"},
+	}})
+	if diags := r.CreateContext(context.Background(), d, meta); !diags.HasError() {
+		t.Fatal("expected the failed notification to fail the create")
+	}
+
+	// A form in the state would be tainted, and replacing it would delete
+	// its submissions.
+	if d.Id() != "" {
+		t.Fatalf("expected the form not to be saved, got ID %q", d.Id())
+	}
+}
+
+func TestAccForm_notFound(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckSiteDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccFormConfig,
+				ExpectError: regexp.MustCompile(`Form "contact" not found`),
+			},
+		},
+	})
+}
+
+var testAccFormConfig = `
+resource "netlify_site" "test" {}
+
+resource "netlify_form" "test" {
+	site_id = "${netlify_site.test.id}"
+	name = "contact"
+
+	submission_notification {
+		type = "email"
+		data = {
+			email = "test@example.com"
+		}
+	}
+}
+`
@@ -1,6 +1,14 @@
 package netlify
 
 import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/netlify/open-api/v2/go/models"
 	"github.com/netlify/open-api/v2/go/plumbing/operations"
@@ -8,12 +16,20 @@ import (
 
 func resourceHook() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceHookCreate,
-		Read:   resourceHookRead,
-		Update: resourceHookUpdate,
-		Delete: resourceHookDelete,
+		CreateContext: resourceHookCreate,
+		ReadContext:   resourceHookRead,
+		UpdateContext: resourceHookUpdate,
+		DeleteContext: resourceHookDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: resourceHookCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -36,38 +52,44 @@ func resourceHook() *schema.Resource {
 				Type:     schema.TypeMap,
 				Required: true,
 			},
+
+			"disabled": {
+				Type:        schema.TypeBool,
+				Description: "Whether the hook is disabled. Netlify disables hooks that fail repeatedly; they are re-enabled unless this is set.",
+				Optional:    true,
+			},
 		},
 	}
 }
 
-func resourceHookCreate(d *schema.ResourceData, metaRaw interface{}) error {
-	params := operations.NewCreateHookBySiteIDParams()
+func resourceHookCreate(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	params := operations.NewCreateHookBySiteIDParamsWithContext(c)
 	params.SiteID = d.Get("site_id").(string)
 	params.Hook = resourceHook_struct(d)
 
 	meta := metaRaw.(*Meta)
 	resp, err := meta.Netlify.Operations.CreateHookBySiteID(params, meta.AuthInfo)
 	if err != nil {
-		return err
+		return apiErrorDiags(err, cty.GetAttrPath("site_id"))
 	}
 
 	d.SetId(resp.Payload.ID)
-	return resourceHookRead(d, metaRaw)
+	return resourceHookRead(c, d, metaRaw)
 }
 
-func resourceHookRead(d *schema.ResourceData, metaRaw interface{}) error {
+func resourceHookRead(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
 	meta := metaRaw.(*Meta)
-	params := operations.NewGetHookParams()
+	params := operations.NewGetHookParamsWithContext(c)
 	params.HookID = d.Id()
 	resp, err := meta.Netlify.Operations.GetHook(params, meta.AuthInfo)
 	if err != nil {
 		// If it is a 404 it was removed remotely
-		if v, ok := err.(*operations.GetHookDefault); ok && v.Code() == 404 {
+		if isNotFound(err) {
 			d.SetId("")
 			return nil
 		}
 
-		return err
+		return apiErrorDiags(err, nil)
 	}
 
 	hook := resp.Payload
@@ -75,37 +97,157 @@ func resourceHookRead(d *schema.ResourceData, metaRaw interface{}) error {
 	d.Set("type", hook.Type)
 	d.Set("event", hook.Event)
 	d.Set("data", hook.Data)
+	d.Set("disabled", hook.Disabled)
 
 	return nil
 }
 
-func resourceHookUpdate(d *schema.ResourceData, metaRaw interface{}) error {
-	params := operations.NewUpdateHookParams()
+func resourceHookUpdate(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	params := operations.NewUpdateHookParamsWithContext(c)
 	params.HookID = d.Id()
 	params.Hook = resourceHook_struct(d)
 
 	meta := metaRaw.(*Meta)
 	_, err := meta.Netlify.Operations.UpdateHook(params, meta.AuthInfo)
 	if err != nil {
-		return err
+		return apiErrorDiags(err, nil)
 	}
 
-	return resourceHookRead(d, metaRaw)
+	// Updating a hook does not re-enable one that Netlify has disabled.
+	if d.HasChange("disabled") && !d.Get("disabled").(bool) {
+		if err := resourceHook_enable(c, meta, d.Id()); err != nil {
+			return apiErrorDiags(err, nil)
+		}
+	}
+
+	return resourceHookRead(c, d, metaRaw)
 }
 
-func resourceHookDelete(d *schema.ResourceData, metaRaw interface{}) error {
+func resourceHookDelete(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
 	meta := metaRaw.(*Meta)
-	params := operations.NewDeleteHookParams()
+	params := operations.NewDeleteHookParamsWithContext(c)
 	params.HookID = d.Id()
 	_, err := meta.Netlify.Operations.DeleteHook(params, meta.AuthInfo)
-	return err
+	return apiErrorDiags(err, nil)
 }
 
 // Returns the Hook structure that can be used for creation or updating.
 func resourceHook_struct(d *schema.ResourceData) *models.Hook {
 	return &models.Hook{
-		Data:  d.Get("data").(map[string]interface{}),
-		Event: d.Get("event").(string),
-		Type:  d.Get("type").(string),
+		Data:     d.Get("data").(map[string]interface{}),
+		Event:    d.Get("event").(string),
+		Type:     d.Get("type").(string),
+		Disabled: d.Get("disabled").(bool),
+	}
+}
+
+// Re-enables a hook that has been disabled.
+func resourceHook_enable(c context.Context, meta *Meta, hookID string) error {
+	params := operations.NewEnableHookParamsWithContext(c)
+	params.HookID = hookID
+	_, err := meta.Netlify.Operations.EnableHook(params, meta.AuthInfo)
+	return err
+}
+
+// Validates the type, event and data of the hook against the hook types
+// Netlify supports, so mistakes are reported during plan rather than apply.
+func resourceHookCustomizeDiff(c context.Context, d *schema.ResourceDiff, metaRaw interface{}) error {
+	if !d.NewValueKnown("type") || !d.NewValueKnown("event") {
+		return nil
+	}
+
+	meta := metaRaw.(*Meta)
+	resp, err := meta.Netlify.Operations.ListHookTypes(operations.NewListHookTypesParamsWithContext(c), meta.AuthInfo)
+	if err != nil {
+		return err
+	}
+
+	var data map[string]interface{}
+	if d.NewValueKnown("data") {
+		data = d.Get("data").(map[string]interface{})
+	}
+
+	return validateHook(resp.Payload, d.Get("type").(string), d.Get("event").(string), data)
+}
+
+// Checks a hook against the list of hook types. Data is only checked when it
+// is non-nil.
+func validateHook(types []*models.HookType, hookType, event string, data map[string]interface{}) error {
+	var ht *models.HookType
+	names := []string{}
+	for _, t := range types {
+		names = append(names, t.Name)
+		if t.Name == hookType {
+			ht = t
+		}
+	}
+	if ht == nil {
+		sort.Strings(names)
+		return fmt.Errorf("Invalid hook type %q. Must be one of: %s", hookType, strings.Join(names, ", "))
+	}
+
+	validEvent := false
+	for _, e := range ht.Events {
+		if e == event {
+			validEvent = true
+			break
+		}
+	}
+	if !validEvent {
+		return fmt.Errorf("Invalid event %q for hook type %q. Must be one of: %s", event, hookType, strings.Join(ht.Events, ", "))
+	}
+
+	if data == nil {
+		return nil
+	}
+
+	fields := map[string]bool{}
+	var fieldNames, missing []string
+	for _, f := range ht.Fields {
+		name, required := hookTypeField(f)
+		if name == "" {
+			continue
+		}
+
+		fields[name] = true
+		fieldNames = append(fieldNames, name)
+		if _, ok := data[name]; required && !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("Hook type %q is missing required data fields: %s", hookType, strings.Join(missing, ", "))
+	}
+
+	// Only check for unknown fields if the hook type describes its fields.
+	if len(fields) == 0 {
+		return nil
+	}
+
+	var unknown []string
+	for k := range data {
+		if !fields[k] {
+			unknown = append(unknown, k)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("Unknown data fields for hook type %q: %s. Valid fields are: %s", hookType, strings.Join(unknown, ", "), strings.Join(fieldNames, ", "))
+	}
+
+	return nil
+}
+
+// Returns the name of a hook type field and whether it is required. Fields
+// are either plain names or objects describing the field.
+func hookTypeField(f interface{}) (string, bool) {
+	switch v := f.(type) {
+	case string:
+		return v, false
+	case map[string]interface{}:
+		name, _ := v["name"].(string)
+		required, _ := v["required"].(bool)
+		return name, required
 	}
+	return "", false
 }
@@ -15,9 +15,9 @@ func TestAccHook(t *testing.T) {
 	resourceName := "netlify_hook.test"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
-		CheckDestroy:      testAccCheckHookDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckHookDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccHookConfig,
@@ -48,9 +48,9 @@ func TestAccHook_disappears(t *testing.T) {
 	}
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
-		CheckDestroy:      testAccCheckHookDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckHookDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccHookConfig,
@@ -69,9 +69,9 @@ func TestAccHook_updateData(t *testing.T) {
 	resourceName := "netlify_hook.test"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
-		CheckDestroy:      testAccCheckHookDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckHookDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccHookConfig,
@@ -98,6 +98,78 @@ func TestAccHook_updateData(t *testing.T) {
 	})
 }
 
+func TestAccHook_disabled(t *testing.T) {
+	var hook models.Hook
+	resourceName := "netlify_hook.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckHookDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHookConfig_disabled,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckHookExists(resourceName, &hook),
+					testAccAssert("is disabled", func() bool {
+						return hook.Disabled
+					}),
+				),
+			},
+
+			{
+				Config: testAccHookConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckHookExists(resourceName, &hook),
+					testAccAssert("is re-enabled", func() bool {
+						return !hook.Disabled
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestValidateHook(t *testing.T) {
+	types := []*models.HookType{
+		{
+			Name:   "url",
+			Events: []string{"deploy_created", "deploy_locked"},
+			Fields: []interface{}{
+				map[string]interface{}{"name": "url", "required": true},
+				map[string]interface{}{"name": "signature_secret"},
+			},
+		},
+		{
+			Name:   "email",
+			Events: []string{"deploy_failed"},
+		},
+	}
+
+	cases := []struct {
+		name    string
+		typ     string
+		event   string
+		data    map[string]interface{}
+		wantErr bool
+	}{
+		{"valid", "url", "deploy_locked", map[string]interface{}{"url": "http://example.com"}, false},
+		{"unknown data", "url", "deploy_locked", nil, false},
+		{"invalid type", "slack", "deploy_locked", nil, true},
+		{"invalid event", "url", "deploy_failed", nil, true},
+		{"missing field", "url", "deploy_locked", map[string]interface{}{"signature_secret": "s"}, true},
+		{"unknown field", "url", "deploy_locked", map[string]interface{}{"url": "u", "uri": "u"}, true},
+		{"undescribed fields", "email", "deploy_failed", map[string]interface{}{"email": "a@example.com"}, false},
+	}
+
+	for _, tc := range cases {
+		err := validateHook(types, tc.typ, tc.event, tc.data)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%s: expected error %t, got %v", tc.name, tc.wantErr, err)
+		}
+	}
+}
+
 func testAccCheckHookExists(n string, hook *models.Hook) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -137,7 +209,7 @@ func testAccCheckHookDestroy(s *terraform.State) error {
 		}
 
 		if err != nil {
-			if v, ok := err.(*operations.GetHookDefault); ok && v.Code() == 404 {
+			if isNotFound(err) {
 				return nil
 			}
 		}
@@ -172,3 +244,17 @@ resource "netlify_hook" "test" {
 	}
 }
 `
+
+var testAccHookConfig_disabled = `
+resource "netlify_site" "test" {}
+
+resource "netlify_hook" "test" {
+	site_id = "${netlify_site.test.id}"
+	type  = "url"
+	event = "deploy_locked"
+	disabled = true
+	data  = {
+		url = "http://www.example.com"
+	}
+}
+`
@@ -0,0 +1,233 @@
+package netlify
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/netlify/open-api/v2/go/models"
+	"github.com/netlify/open-api/v2/go/plumbing/operations"
+)
+
+// A notification is a hook of a fixed type whose data is described by typed
+// attributes instead of a free-form map.
+type notification struct {
+	// The hook type, e.g. "slack".
+	hookType string
+
+	// The attributes that make up the hook data, in addition to the common
+	// site_id, event and disabled attributes.
+	fields map[string]*schema.Schema
+
+	// The description of the disabled attribute, which says why Netlify may
+	// disable the notification.
+	disabledDescription string
+}
+
+func resourceNotificationSlack() *schema.Resource {
+	return notification{
+		hookType: "slack",
+		fields: map[string]*schema.Schema{
+			"url": {
+				Type:        schema.TypeString,
+				Description: "The Slack incoming webhook URL to post notifications to.",
+				Required:    true,
+				Sensitive:   true,
+			},
+		},
+		disabledDescription: "Whether the Slack notification is disabled. Netlify disables it when Slack keeps rejecting the webhook, e.g. after the app is removed from the channel; applying enables it again unless this is set.",
+	}.resource("Sends a Slack message when a deploy event occurs on a site.")
+}
+
+func resourceNotificationEmail() *schema.Resource {
+	return notification{
+		hookType: "email",
+		fields: map[string]*schema.Schema{
+			"email": {
+				Type:        schema.TypeString,
+				Description: "The email address to send notifications to.",
+				Required:    true,
+			},
+		},
+		disabledDescription: "Whether the email notification is disabled. Netlify disables it when mail to the address keeps bouncing; applying enables it again unless this is set.",
+	}.resource("Sends an email when a deploy event occurs on a site.")
+}
+
+func resourceNotificationGithubCommitStatus() *schema.Resource {
+	return notification{
+		hookType: "github_commit_status",
+		fields: map[string]*schema.Schema{
+			"access_token": {
+				Type:        schema.TypeString,
+				Description: "The GitHub access token used to set commit statuses on the site's repository.",
+				Required:    true,
+				Sensitive:   true,
+			},
+		},
+		disabledDescription: "Whether the commit status notification is disabled. Netlify disables it when GitHub keeps rejecting the access token, e.g. after it is revoked; applying enables it again unless this is set.",
+	}.resource("Sets a GitHub commit status when a deploy event occurs on a site.")
+}
+
+func (n notification) resource(description string) *schema.Resource {
+	s := map[string]*schema.Schema{
+		"site_id": {
+			Type:        schema.TypeString,
+			Description: "The ID of the site to send notifications for.",
+			Required:    true,
+			ForceNew:    true,
+		},
+
+		"event": {
+			Type:        schema.TypeString,
+			Description: "The deploy event that triggers the notification, e.g. `deploy_failed`.",
+			Required:    true,
+		},
+
+		"disabled": {
+			Type:        schema.TypeBool,
+			Description: n.disabledDescription,
+			Optional:    true,
+		},
+	}
+	for k, v := range n.fields {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		Description:   description,
+		CreateContext: n.create,
+		ReadContext:   n.read,
+		UpdateContext: n.update,
+		DeleteContext: n.delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: n.customizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: s,
+	}
+}
+
+func (n notification) create(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	params := operations.NewCreateHookBySiteIDParamsWithContext(c)
+	params.SiteID = d.Get("site_id").(string)
+	params.Hook = n.hook(d)
+
+	meta := metaRaw.(*Meta)
+	resp, err := meta.Netlify.Operations.CreateHookBySiteID(params, meta.AuthInfo)
+	if err != nil {
+		return apiErrorDiags(err, cty.GetAttrPath("site_id"))
+	}
+
+	d.SetId(resp.Payload.ID)
+	return n.read(c, d, metaRaw)
+}
+
+func (n notification) read(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	meta := metaRaw.(*Meta)
+	params := operations.NewGetHookParamsWithContext(c)
+	params.HookID = d.Id()
+	resp, err := meta.Netlify.Operations.GetHook(params, meta.AuthInfo)
+	if err != nil {
+		// If it is a 404 it was removed remotely
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+
+		return apiErrorDiags(err, nil)
+	}
+
+	hook := resp.Payload
+	d.Set("site_id", hook.SiteID)
+	d.Set("event", hook.Event)
+	d.Set("disabled", hook.Disabled)
+
+	data, _ := hook.Data.(map[string]interface{})
+	for k, field := range n.fields {
+		v, _ := data[k].(string)
+		// The API may mask secrets, which would never match the configured
+		// value, so the configured value is kept instead.
+		if field.Sensitive && isMaskedSecret(v) {
+			continue
+		}
+		d.Set(k, v)
+	}
+
+	return nil
+}
+
+func (n notification) update(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	params := operations.NewUpdateHookParamsWithContext(c)
+	params.HookID = d.Id()
+	params.Hook = n.hook(d)
+
+	meta := metaRaw.(*Meta)
+	_, err := meta.Netlify.Operations.UpdateHook(params, meta.AuthInfo)
+	if err != nil {
+		return apiErrorDiags(err, nil)
+	}
+
+	// Updating a hook does not re-enable one that Netlify has disabled.
+	if d.HasChange("disabled") && !d.Get("disabled").(bool) {
+		if err := resourceHook_enable(c, meta, d.Id()); err != nil {
+			return apiErrorDiags(err, nil)
+		}
+	}
+
+	return n.read(c, d, metaRaw)
+}
+
+func (n notification) delete(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	meta := metaRaw.(*Meta)
+	params := operations.NewDeleteHookParamsWithContext(c)
+	params.HookID = d.Id()
+	_, err := meta.Netlify.Operations.DeleteHook(params, meta.AuthInfo)
+	return apiErrorDiags(err, nil)
+}
+
+// Validates the event against the events supported by the hook type.
+func (n notification) customizeDiff(c context.Context, d *schema.ResourceDiff, metaRaw interface{}) error {
+	if !d.NewValueKnown("event") {
+		return nil
+	}
+
+	meta := metaRaw.(*Meta)
+	resp, err := meta.Netlify.Operations.ListHookTypes(operations.NewListHookTypesParamsWithContext(c), meta.AuthInfo)
+	if err != nil {
+		return err
+	}
+
+	return validateHook(resp.Payload, n.hookType, d.Get("event").(string), nil)
+}
+
+// Returns the Hook structure that can be used for creation or updating.
+func (n notification) hook(d *schema.ResourceData) *models.Hook {
+	data := map[string]interface{}{}
+	for k := range n.fields {
+		data[k] = d.Get(k)
+	}
+
+	return &models.Hook{
+		Type:     n.hookType,
+		Event:    d.Get("event").(string),
+		Data:     data,
+		Disabled: d.Get("disabled").(bool),
+	}
+}
+
+// Returns whether a secret returned by the API is masked, e.g. "****abcd",
+// rather than the secret itself.
+func isMaskedSecret(v string) bool {
+	return v == "" || strings.Contains(v, "***")
+}
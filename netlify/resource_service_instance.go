@@ -0,0 +1,205 @@
+package netlify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/netlify/open-api/v2/go/plumbing/operations"
+)
+
+func resourceServiceInstance() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Provisions an add-on service instance on a site.",
+		CreateContext: resourceServiceInstanceCreate,
+		ReadContext:   resourceServiceInstanceRead,
+		UpdateContext: resourceServiceInstanceUpdate,
+		DeleteContext: resourceServiceInstanceDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceServiceInstanceImport,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"site_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the site to add the service to.",
+				Required:    true,
+				ForceNew:    true,
+			},
+
+			"addon": {
+				Type:        schema.TypeString,
+				Description: "The slug of the add-on service, as listed by the `netlify_services` data source.",
+				Required:    true,
+				ForceNew:    true,
+			},
+
+			"config": {
+				Type:        schema.TypeMap,
+				Description: "The configuration of the service instance. Only the keys set here are read back, except on import, which reads the whole configuration including the defaults of the service.",
+				Optional:    true,
+				Sensitive:   true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"env": {
+				Type:        schema.TypeMap,
+				Description: "The environment variables the service instance provides to the site.",
+				Computed:    true,
+				Sensitive:   true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"auth_url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"service_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceServiceInstanceCreate(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	meta := metaRaw.(*Meta)
+	params := operations.NewCreateServiceInstanceParamsWithContext(c)
+	params.SiteID = d.Get("site_id").(string)
+	params.Addon = d.Get("addon").(string)
+	params.Config = d.Get("config").(map[string]interface{})
+
+	resp, err := meta.Netlify.Operations.CreateServiceInstance(params, meta.AuthInfo)
+	if err != nil {
+		return apiErrorDiags(err, cty.GetAttrPath("addon"))
+	}
+
+	d.SetId(resp.Payload.ID)
+	return resourceServiceInstanceRead(c, d, metaRaw)
+}
+
+func resourceServiceInstanceRead(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	meta := metaRaw.(*Meta)
+	params := operations.NewShowServiceInstanceParamsWithContext(c)
+	params.SiteID = d.Get("site_id").(string)
+	params.Addon = d.Get("addon").(string)
+	params.InstanceID = d.Id()
+	resp, err := meta.Netlify.Operations.ShowServiceInstance(params, meta.AuthInfo)
+	if err != nil {
+		// If it is a 404 it was removed remotely
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+
+		return apiErrorDiags(err, nil)
+	}
+
+	instance := resp.Payload
+
+	// Services may add their own defaults to the config, so only the keys
+	// that are managed here are read back.
+	if remote, ok := instance.Config.(map[string]interface{}); ok {
+		config := map[string]interface{}{}
+		for k := range d.Get("config").(map[string]interface{}) {
+			if v, ok := remote[k]; ok {
+				config[k] = fmt.Sprint(v)
+			}
+		}
+		d.Set("config", config)
+	}
+
+	env := map[string]interface{}{}
+	if m, ok := instance.Env.(map[string]interface{}); ok {
+		for k, v := range m {
+			env[k] = fmt.Sprint(v)
+		}
+	}
+	d.Set("env", env)
+	d.Set("url", instance.URL)
+	d.Set("auth_url", instance.AuthURL)
+	d.Set("service_name", instance.ServiceName)
+
+	return nil
+}
+
+func resourceServiceInstanceUpdate(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	meta := metaRaw.(*Meta)
+	params := operations.NewUpdateServiceInstanceParamsWithContext(c)
+	params.SiteID = d.Get("site_id").(string)
+	params.Addon = d.Get("addon").(string)
+	params.InstanceID = d.Id()
+	params.Config = d.Get("config").(map[string]interface{})
+
+	_, err := meta.Netlify.Operations.UpdateServiceInstance(params, meta.AuthInfo)
+	if err != nil {
+		return apiErrorDiags(err, nil)
+	}
+
+	return resourceServiceInstanceRead(c, d, metaRaw)
+}
+
+func resourceServiceInstanceDelete(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	meta := metaRaw.(*Meta)
+	params := operations.NewDeleteServiceInstanceParamsWithContext(c)
+	params.SiteID = d.Get("site_id").(string)
+	params.Addon = d.Get("addon").(string)
+	params.InstanceID = d.Id()
+	_, err := meta.Netlify.Operations.DeleteServiceInstance(params, meta.AuthInfo)
+	return apiErrorDiags(err, nil)
+}
+
+// Imports a service instance from an ID of the form <site_id>/<addon>/<instance_id>.
+func resourceServiceInstanceImport(c context.Context, d *schema.ResourceData, metaRaw interface{}) ([]*schema.ResourceData, error) {
+	split := strings.Split(d.Id(), "/")
+	if len(split) != 3 {
+		return nil, fmt.Errorf("Invalid import ID %q, expected <site_id>/<addon>/<instance_id>", d.Id())
+	}
+
+	d.Set("site_id", split[0])
+	d.Set("addon", split[1])
+	d.SetId(split[2])
+
+	// Read only reads back the config keys in the state, which has none yet,
+	// so the whole config is imported, including the service's defaults.
+	meta := metaRaw.(*Meta)
+	params := operations.NewShowServiceInstanceParamsWithContext(c)
+	params.SiteID = split[0]
+	params.Addon = split[1]
+	params.InstanceID = split[2]
+	resp, err := meta.Netlify.Operations.ShowServiceInstance(params, meta.AuthInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	config := map[string]interface{}{}
+	if remote, ok := resp.Payload.Config.(map[string]interface{}); ok {
+		for k, v := range remote {
+			config[k] = fmt.Sprint(v)
+		}
+	}
+	d.Set("config", config)
+
+	return []*schema.ResourceData{d}, nil
+}
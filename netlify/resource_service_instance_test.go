@@ -0,0 +1,105 @@
+package netlify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/netlify/open-api/v2/go/plumbing/operations"
+)
+
+func TestAccServiceInstance(t *testing.T) {
+	testAccSkipFake(t)
+
+	addon := os.Getenv("NETLIFY_TEST_ADDON")
+	if addon == "" {
+		t.Skip("NETLIFY_TEST_ADDON must be set to test service instances")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckServiceInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccServiceInstanceConfig, addon),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("netlify_service_instance.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestServiceInstanceImport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/sites/site/services/addon/instances/instance" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "instance", "config": {"plan": "free", "region": "eu"}}`))
+	}))
+	defer server.Close()
+
+	config := Config{Token: "token", BaseURL: server.URL}
+	meta, err := config.Client(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := resourceServiceInstance()
+	d := r.TestResourceData()
+	d.SetId("site/addon/instance")
+	if _, err := r.Importer.StateContext(context.Background(), d, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	if d.Id() != "instance" || d.Get("site_id") != "site" || d.Get("addon") != "addon" {
+		t.Fatalf("unexpected ID %q, site_id %q and addon %q", d.Id(), d.Get("site_id"), d.Get("addon"))
+	}
+	if got := d.Get("config").(map[string]interface{}); len(got) != 2 || got["plan"] != "free" || got["region"] != "eu" {
+		t.Fatalf("expected the whole config to be imported, got %v", got)
+	}
+}
+
+func testAccCheckServiceInstanceDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "netlify_service_instance" {
+			continue
+		}
+
+		meta := testAccProvider.Meta().(*Meta)
+		params := operations.NewShowServiceInstanceParams()
+		params.SiteID = rs.Primary.Attributes["site_id"]
+		params.Addon = rs.Primary.Attributes["addon"]
+		params.InstanceID = rs.Primary.ID
+		resp, err := meta.Netlify.Operations.ShowServiceInstance(params, meta.AuthInfo)
+		if err == nil && resp.Payload != nil {
+			return fmt.Errorf("Service instance still exists: %s", rs.Primary.ID)
+		}
+
+		if err != nil {
+			if isNotFound(err) {
+				return nil
+			}
+		}
+
+		return err
+	}
+
+	return testAccCheckSiteDestroy(s)
+}
+
+var testAccServiceInstanceConfig = `
+resource "netlify_site" "test" {}
+
+resource "netlify_service_instance" "test" {
+	site_id = netlify_site.test.id
+	addon = "%s"
+}
+`
@@ -1,220 +1,602 @@
 package netlify
 
 import (
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sdkresource "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/netlify/open-api/v2/go/models"
 	"github.com/netlify/open-api/v2/go/plumbing/operations"
 )
 
-func resourceSite() *schema.Resource {
-	return &schema.Resource{
-		Create: resourceSiteCreate,
-		Read:   resourceSiteRead,
-		Update: resourceSiteUpdate,
-		Delete: resourceSiteDelete,
-		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
-		},
+var (
+	_ resource.ResourceWithConfigure      = &siteResource{}
+	_ resource.ResourceWithImportState    = &siteResource{}
+	_ resource.ResourceWithModifyPlan     = &siteResource{}
+	_ resource.ResourceWithUpgradeState   = &siteResource{}
+	_ resource.ResourceWithValidateConfig = &siteResource{}
+)
+
+// The page size used when looking up sites by name.
+const sitesPerPage = 100
+
+// siteResource is implemented with the plugin framework. Its repo block was a
+// list of at most one element in the SDK version of the schema (version 0).
+type siteResource struct {
+	meta *Meta
+}
+
+func newSiteResource() resource.Resource {
+	return &siteResource{}
+}
+
+type siteModel struct {
+	ID            types.String   `tfsdk:"id"`
+	Name          types.String   `tfsdk:"name"`
+	CustomDomain  types.String   `tfsdk:"custom_domain"`
+	DeployURL     types.String   `tfsdk:"deploy_url"`
+	AccountSlug   types.String   `tfsdk:"account_slug"`
+	AccountName   types.String   `tfsdk:"account_name"`
+	WaitForDeploy types.Bool     `tfsdk:"wait_for_deploy"`
+	Repo          *siteRepoModel `tfsdk:"repo"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
 
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:     schema.TypeString,
+type siteRepoModel struct {
+	Command        types.String `tfsdk:"command"`
+	DeployKeyID    types.String `tfsdk:"deploy_key_id"`
+	Dir            types.String `tfsdk:"dir"`
+	Provider       types.String `tfsdk:"provider"`
+	RepoPath       types.String `tfsdk:"repo_path"`
+	RepoBranch     types.String `tfsdk:"repo_branch"`
+	InstallationID types.Int64  `tfsdk:"installation_id"`
+}
+
+func (r *siteResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_site"
+}
+
+func (r *siteResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: 1,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"name": schema.StringAttribute{
 				Optional: true,
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 
-			"custom_domain": {
-				Type:     schema.TypeString,
+			"custom_domain": schema.StringAttribute{
 				Optional: true,
 			},
 
-			"deploy_url": {
-				Type:     schema.TypeString,
+			"deploy_url": schema.StringAttribute{
 				Computed: true,
 			},
 
-			"account_slug": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
+			"account_slug": schema.StringAttribute{
+				MarkdownDescription: "The slug of the account to create the site in. Defaults to the provider's `default_account_slug`, or the user's personal account.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 
-			"account_name": {
-				Type:     schema.TypeString,
+			"account_name": schema.StringAttribute{
 				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 
-			"repo": {
-				Type:     schema.TypeList,
-				MaxItems: 1,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"command": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
+			"wait_for_deploy": schema.BoolAttribute{
+				MarkdownDescription: "Wait for the initial production deploy of a site with a `repo` to be live before finishing creation.",
+				Optional:            true,
+			},
+		},
 
-						"deploy_key_id": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
+		Blocks: map[string]schema.Block{
+			"repo": schema.SingleNestedBlock{
+				Description: "The repository the site is built from. It cannot be removed once set, since the Netlify API cannot unlink it.",
+				Attributes: map[string]schema.Attribute{
+					"command": schema.StringAttribute{
+						Optional: true,
+					},
 
-						"dir": {
-							Type:     schema.TypeString,
-							Optional: true,
+					"deploy_key_id": schema.StringAttribute{
+						Optional: true,
+						Computed: true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
 						},
+					},
 
-						"provider": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
+					"dir": schema.StringAttribute{
+						Optional: true,
+					},
 
-						"repo_path": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
+					"provider": schema.StringAttribute{
+						MarkdownDescription: "Required in a `repo` block.",
+						Optional:            true,
+					},
 
-						"repo_branch": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
+					"repo_path": schema.StringAttribute{
+						MarkdownDescription: "Required in a `repo` block.",
+						Optional:            true,
+					},
+
+					"repo_branch": schema.StringAttribute{
+						MarkdownDescription: "Required in a `repo` block.",
+						Optional:            true,
+					},
 
-						"installation_id": {
-							Type:     schema.TypeInt,
-							Computed: true,
+					"installation_id": schema.Int64Attribute{
+						Computed: true,
+						PlanModifiers: []planmodifier.Int64{
+							int64planmodifier.UseStateForUnknown(),
 						},
 					},
 				},
 			},
+
+			"timeouts": timeouts.BlockAll(ctx),
 		},
 	}
 }
 
-func resourceSiteCreate(d *schema.ResourceData, metaRaw interface{}) error {
-	meta := metaRaw.(*Meta)
+// The repo attributes that must be set, which are optional in the schema as
+// attributes of a missing single nested block are still validated.
+func (r *siteResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var repo types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("repo"), &repo)...)
+	if resp.Diagnostics.HasError() || repo.IsNull() || repo.IsUnknown() {
+		return
+	}
+
+	for _, name := range []string{"provider", "repo_path", "repo_branch"} {
+		if v := repo.Attributes()[name]; v != nil && v.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("repo").AtName(name),
+				"Missing required argument",
+				fmt.Sprintf("The argument %q is required in a repo block.", name),
+			)
+		}
+	}
+}
+
+// The API cannot unlink a repository, so the repo block cannot be removed: the
+// repository would still be read back after the update.
+func (r *siteResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var stateRepo, planRepo types.Object
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("repo"), &stateRepo)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("repo"), &planRepo)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !stateRepo.IsNull() && planRepo.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("repo"),
+			"Cannot remove repo",
+			"The site's repository cannot be unlinked through the Netlify API. Unlink it in the Netlify UI before removing the repo block.",
+		)
+	}
+}
+
+func (r *siteResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	r.meta = frameworkMeta(req.ProviderData, &resp.Diagnostics)
+}
+
+func (r *siteResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan siteModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// If we are trying to create a site using a private repository (i.e. not
-	// a public_repo) then we need to get an installation id for the provider.
-	// The easiest (and only way I can see) of doing this is by querying a user's
-	// pre-existing sites and getting it from there.
+	createTimeout, diags := plan.Timeouts.Create(ctx, 20*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
-	// If we have an "account_slug" set we use a different API path that lets
-	// us create a site in a specific team. Unfortunately we have to duplicate
+	// If we have an account slug we use a different API path that lets us
+	// create a site in a specific team. Unfortunately we have to duplicate
 	// a lot of stuff because the types are totally different even though
 	// structurally they are identical.
 	var site *models.Site
-	if v, ok := d.GetOk("account_slug"); ok {
-		params := operations.NewCreateSiteInTeamParams()
-		params.AccountSlug = v.(string)
-		params.Site = resourceSite_setupStruct(d)
-		resp, err := meta.Netlify.Operations.CreateSiteInTeam(params, meta.AuthInfo)
+	slug := plan.AccountSlug.ValueString()
+	if slug == "" {
+		slug = r.meta.DefaultAccountSlug
+	}
+	if slug != "" {
+		params := operations.NewCreateSiteInTeamParamsWithContext(ctx)
+		params.AccountSlug = slug
+		params.Site = plan.setupStruct()
+		createResp, err := r.meta.Netlify.Operations.CreateSiteInTeam(params, r.meta.AuthInfo)
 		if err != nil {
-			return err
+			addAPIError(&resp.Diagnostics, err, path.Root("account_slug"))
+			return
 		}
 
-		site = resp.Payload
+		site = createResp.Payload
 	} else {
-		params := operations.NewCreateSiteParams()
-		params.Site = resourceSite_setupStruct(d)
-		resp, err := meta.Netlify.Operations.CreateSite(params, meta.AuthInfo)
+		params := operations.NewCreateSiteParamsWithContext(ctx)
+		params.Site = plan.setupStruct()
+		createResp, err := r.meta.Netlify.Operations.CreateSite(params, r.meta.AuthInfo)
 		if err != nil {
-			return err
+			addAPIError(&resp.Diagnostics, err, path.Empty())
+			return
 		}
 
-		site = resp.Payload
+		site = createResp.Payload
 	}
 
-	d.SetId(site.ID)
-	return resourceSiteRead(d, metaRaw)
+	// The site is saved before waiting, so that it is tainted rather than
+	// lost if the deploy fails.
+	plan.setSite(site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Repo != nil && plan.WaitForDeploy.ValueBool() {
+		if err := resourceSite_waitForDeploy(ctx, r.meta, site.ID, createTimeout); err != nil {
+			addAPIError(&resp.Diagnostics, err, path.Empty())
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, &plan, &resp.State)...)
 }
 
-func resourceSiteRead(d *schema.ResourceData, metaRaw interface{}) error {
-	meta := metaRaw.(*Meta)
-	params := operations.NewGetSiteParams()
-	params.SiteID = d.Id()
-	resp, err := meta.Netlify.Operations.GetSite(params, meta.AuthInfo)
+func (r *siteResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state siteModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(r.read(ctx, &state, &resp.State)...)
+}
+
+func (r *siteResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan siteModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	params := operations.NewUpdateSiteParamsWithContext(ctx)
+	params.Site = plan.setupStruct()
+	params.SiteID = plan.ID.ValueString()
+
+	r.meta.SiteLocks.Lock(params.SiteID)
+	_, err := r.meta.Netlify.Operations.UpdateSite(params, r.meta.AuthInfo)
+	r.meta.SiteLocks.Unlock(params.SiteID)
 	if err != nil {
-		// If it is a 404 it was removed remotely
-		if v, ok := err.(*operations.GetSiteDefault); ok && v.Code() == 404 {
-			d.SetId("")
-			return nil
-		}
+		addAPIError(&resp.Diagnostics, err, path.Empty())
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, &plan, &resp.State)...)
+}
 
-		return err
+func (r *siteResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state siteModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	site := resp.Payload
-	d.Set("name", site.Name)
-	d.Set("custom_domain", site.CustomDomain)
-	d.Set("deploy_url", site.DeployURL)
-	d.Set("account_slug", site.AccountSlug)
-	d.Set("account_name", site.AccountName)
-	d.Set("repo", nil)
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 5*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
-	if site.BuildSettings != nil && site.BuildSettings.RepoPath != "" {
-		d.Set("repo", []interface{}{
-			map[string]interface{}{
-				"command":         site.BuildSettings.Cmd,
-				"deploy_key_id":   site.BuildSettings.DeployKeyID,
-				"dir":             site.BuildSettings.Dir,
-				"provider":        site.BuildSettings.Provider,
-				"repo_path":       site.BuildSettings.RepoPath,
-				"repo_branch":     site.BuildSettings.RepoBranch,
-				"installation_id": site.BuildSettings.InstallationID,
-			},
-		})
+	params := operations.NewDeleteSiteParamsWithContext(ctx)
+	params.SiteID = state.ID.ValueString()
+	if _, err := r.meta.Netlify.Operations.DeleteSite(params, r.meta.AuthInfo); err != nil {
+		addAPIError(&resp.Diagnostics, err, path.Empty())
 	}
+}
 
-	return nil
+// Sites are imported by their ID, or else by their name.
+func (r *siteResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	params := operations.NewGetSiteParamsWithContext(ctx)
+	params.SiteID = req.ID
+	getResp, err := r.meta.Netlify.Operations.GetSite(params, r.meta.AuthInfo)
+	if err == nil {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), getResp.Payload.ID)...)
+		return
+	}
+	if !isNotFound(err) {
+		addAPIError(&resp.Diagnostics, err, path.Empty())
+		return
+	}
+
+	site, err := findSiteByName(ctx, r.meta, req.ID)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, err, path.Empty())
+		return
+	}
+	if site == nil {
+		resp.Diagnostics.AddError("Cannot import non-existent remote object", fmt.Sprintf("No site has the ID or name %q.", req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), site.ID)...)
 }
 
-func resourceSiteUpdate(d *schema.ResourceData, metaRaw interface{}) error {
-	params := operations.NewUpdateSiteParams()
-	params.Site = resourceSite_setupStruct(d)
-	params.SiteID = d.Id()
+// Reads the site into the model and saves it, removing the resource if the
+// site was removed remotely.
+func (r *siteResource) read(ctx context.Context, m *siteModel, state stateSetter) diag.Diagnostics {
+	var diags diag.Diagnostics
 
-	meta := metaRaw.(*Meta)
-	_, err := meta.Netlify.Operations.UpdateSite(params, meta.AuthInfo)
+	params := operations.NewGetSiteParamsWithContext(ctx)
+	params.SiteID = m.ID.ValueString()
+	resp, err := r.meta.Netlify.Operations.GetSite(params, r.meta.AuthInfo)
 	if err != nil {
-		return err
+		// If it is a 404 it was removed remotely
+		if isNotFound(err) {
+			state.RemoveResource(ctx)
+			return diags
+		}
+
+		addAPIError(&diags, err, path.Empty())
+		return diags
+	}
+
+	m.setSite(resp.Payload)
+	return state.Set(ctx, m)
+}
+
+// Returns the site with exactly the name, or nil if there is none. The API
+// matches names by substring, so the site may be on any page of the results.
+func findSiteByName(ctx context.Context, meta *Meta, name string) (*models.Site, error) {
+	perPage := int32(sitesPerPage)
+	for page := int32(1); ; page++ {
+		params := operations.NewListSitesParamsWithContext(ctx)
+		params.Name = &name
+		params.Page = &page
+		params.PerPage = &perPage
+		resp, err := meta.Netlify.Operations.ListSites(params, meta.AuthInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, site := range resp.Payload {
+			if site.Name == name {
+				return site, nil
+			}
+		}
+
+		if len(resp.Payload) < sitesPerPage {
+			return nil, nil
+		}
 	}
+}
 
-	return resourceSiteRead(d, metaRaw)
+// stateSetter is the part of tfsdk.State that read uses, so that it can be
+// given the state of any response.
+type stateSetter interface {
+	Set(ctx context.Context, val interface{}) diag.Diagnostics
+	RemoveResource(ctx context.Context)
 }
 
-func resourceSiteDelete(d *schema.ResourceData, metaRaw interface{}) error {
-	meta := metaRaw.(*Meta)
-	params := operations.NewDeleteSiteParams()
-	params.SiteID = d.Id()
-	_, err := meta.Netlify.Operations.DeleteSite(params, meta.AuthInfo)
-	return err
+// Sets the model's attributes from the site.
+func (m *siteModel) setSite(site *models.Site) {
+	m.ID = types.StringValue(site.ID)
+	m.Name = types.StringValue(site.Name)
+	m.CustomDomain = nullableString(site.CustomDomain)
+	m.DeployURL = types.StringValue(site.DeployURL)
+	m.AccountSlug = types.StringValue(site.AccountSlug)
+	m.AccountName = types.StringValue(site.AccountName)
+	m.Repo = nil
+
+	if site.BuildSettings != nil && site.BuildSettings.RepoPath != "" {
+		m.Repo = &siteRepoModel{
+			Command:        nullableString(site.BuildSettings.Cmd),
+			DeployKeyID:    nullableString(site.BuildSettings.DeployKeyID),
+			Dir:            nullableString(site.BuildSettings.Dir),
+			Provider:       types.StringValue(site.BuildSettings.Provider),
+			RepoPath:       types.StringValue(site.BuildSettings.RepoPath),
+			RepoBranch:     types.StringValue(site.BuildSettings.RepoBranch),
+			InstallationID: types.Int64Value(site.BuildSettings.InstallationID),
+		}
+	}
 }
 
 // Returns the SiteSetup structure that can be used for creation or updating.
-func resourceSite_setupStruct(d *schema.ResourceData) *models.SiteSetup {
+func (m *siteModel) setupStruct() *models.SiteSetup {
 	result := &models.SiteSetup{
 		Site: models.Site{
-			Name:         d.Get("name").(string),
-			CustomDomain: d.Get("custom_domain").(string),
+			Name:         m.Name.ValueString(),
+			CustomDomain: m.CustomDomain.ValueString(),
 		},
 	}
 
 	// If we have a repo config, then configure that
-	if v, ok := d.GetOk("repo"); ok {
-		vL := v.([]interface{})
-		repo := vL[0].(map[string]interface{})
-
+	if repo := m.Repo; repo != nil {
 		result.Repo = &models.RepoInfo{
-			Cmd:            repo["command"].(string),
-			DeployKeyID:    repo["deploy_key_id"].(string),
-			Dir:            repo["dir"].(string),
-			Provider:       repo["provider"].(string),
-			RepoPath:       repo["repo_path"].(string),
-			RepoBranch:     repo["repo_branch"].(string),
-			InstallationID: int64(repo["installation_id"].(int)),
+			Cmd:            repo.Command.ValueString(),
+			DeployKeyID:    repo.DeployKeyID.ValueString(),
+			Dir:            repo.Dir.ValueString(),
+			Provider:       repo.Provider.ValueString(),
+			RepoPath:       repo.RepoPath.ValueString(),
+			RepoBranch:     repo.RepoBranch.ValueString(),
+			InstallationID: repo.InstallationID.ValueInt64(),
 		}
 	}
 
 	return result
 }
+
+// siteModelV0 is the state of the SDK version of the resource, which stored
+// unset strings as empty ones and the repo block as a list.
+type siteModelV0 struct {
+	ID            types.String    `tfsdk:"id"`
+	Name          types.String    `tfsdk:"name"`
+	CustomDomain  types.String    `tfsdk:"custom_domain"`
+	DeployURL     types.String    `tfsdk:"deploy_url"`
+	AccountSlug   types.String    `tfsdk:"account_slug"`
+	AccountName   types.String    `tfsdk:"account_name"`
+	WaitForDeploy types.Bool      `tfsdk:"wait_for_deploy"`
+	Repo          []siteRepoModel `tfsdk:"repo"`
+	Timeouts      timeouts.Value  `tfsdk:"timeouts"`
+}
+
+func (r *siteResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":              schema.StringAttribute{Optional: true, Computed: true},
+					"name":            schema.StringAttribute{Optional: true, Computed: true},
+					"custom_domain":   schema.StringAttribute{Optional: true},
+					"deploy_url":      schema.StringAttribute{Computed: true},
+					"account_slug":    schema.StringAttribute{Optional: true, Computed: true},
+					"account_name":    schema.StringAttribute{Computed: true},
+					"wait_for_deploy": schema.BoolAttribute{Optional: true},
+				},
+				Blocks: map[string]schema.Block{
+					"repo": schema.ListNestedBlock{
+						NestedObject: schema.NestedBlockObject{
+							Attributes: map[string]schema.Attribute{
+								"command":         schema.StringAttribute{Optional: true},
+								"deploy_key_id":   schema.StringAttribute{Optional: true, Computed: true},
+								"dir":             schema.StringAttribute{Optional: true},
+								"provider":        schema.StringAttribute{Required: true},
+								"repo_path":       schema.StringAttribute{Required: true},
+								"repo_branch":     schema.StringAttribute{Required: true},
+								"installation_id": schema.Int64Attribute{Computed: true},
+							},
+						},
+					},
+					"timeouts": timeouts.BlockAll(ctx),
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior siteModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				upgraded := siteModel{
+					ID:            prior.ID,
+					Name:          prior.Name,
+					CustomDomain:  nullableString(prior.CustomDomain.ValueString()),
+					DeployURL:     prior.DeployURL,
+					AccountSlug:   prior.AccountSlug,
+					AccountName:   prior.AccountName,
+					WaitForDeploy: prior.WaitForDeploy,
+					Timeouts:      prior.Timeouts,
+				}
+				if !prior.WaitForDeploy.ValueBool() {
+					upgraded.WaitForDeploy = types.BoolNull()
+				}
+				if len(prior.Repo) > 0 {
+					repo := prior.Repo[0]
+					repo.Command = nullableString(repo.Command.ValueString())
+					repo.DeployKeyID = nullableString(repo.DeployKeyID.ValueString())
+					repo.Dir = nullableString(repo.Dir.ValueString())
+					upgraded.Repo = &repo
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+			},
+		},
+	}
+}
+
+// Waits until the latest production deploy of the site is live, returning
+// the deploy's error message if it fails.
+func resourceSite_waitForDeploy(c context.Context, meta *Meta, siteID string, timeout time.Duration) error {
+	conf := &sdkresource.StateChangeConf{
+		Pending: []string{"pending"},
+		Target:  []string{"ready"},
+		Refresh: func() (interface{}, string, error) {
+			params := operations.NewListSiteDeploysParamsWithContext(c)
+			params.SiteID = siteID
+			resp, err := meta.Netlify.Operations.ListSiteDeploys(params, meta.AuthInfo)
+			if err != nil {
+				return nil, "", err
+			}
+
+			// Deploys are listed newest first, and the site has just been
+			// created, so the first production deploy is the initial one.
+			for _, deploy := range resp.Payload {
+				if deploy.Context != "production" {
+					continue
+				}
+
+				switch deploy.State {
+				case "ready":
+					return deploy, "ready", nil
+				case "error":
+					return nil, "", fmt.Errorf("Deploy %s failed: %s", deploy.ID, deploy.ErrorMessage)
+				}
+				return deploy, "pending", nil
+			}
+
+			// The first build may not have started yet.
+			return siteID, "pending", nil
+		},
+		Timeout:    timeout,
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	if _, err := conf.WaitForStateContext(c); err != nil {
+		return fmt.Errorf("Error waiting for the initial deploy of site %s: %s", siteID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,221 @@
+package netlify
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/netlify/open-api/v2/go/plumbing/operations"
+	"github.com/netlify/open-api/v2/go/porcelain"
+)
+
+func resourceSiteAsset() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Uploads a local file as a large asset of a site, stored outside of deploys. The asset is uploaded again whenever the contents of the file change.",
+		CreateContext: resourceSiteAssetCreate,
+		ReadContext:   resourceSiteAssetRead,
+		DeleteContext: resourceSiteAssetDelete,
+		CustomizeDiff: resourceSiteAssetCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"site_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the site to upload the asset to.",
+				Required:    true,
+				ForceNew:    true,
+			},
+
+			"source": {
+				Type:        schema.TypeString,
+				Description: "The path of the local file to upload.",
+				Required:    true,
+				ForceNew:    true,
+			},
+
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the asset. Defaults to the file name of `source`.",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+
+			"visibility": {
+				Type:         schema.TypeString,
+				Description:  "Either `public` or `private`. Private assets can only be fetched through `public_signature`.",
+				Optional:     true,
+				Default:      "public",
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"public", "private"}, false),
+			},
+
+			"checksum": {
+				Type:        schema.TypeString,
+				Description: "The SHA256 checksum of the uploaded file.",
+				Computed:    true,
+				ForceNew:    true,
+			},
+
+			"url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"public_signature": {
+				Type:        schema.TypeString,
+				Description: "A signed URL to fetch a private asset, valid for a limited time.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+
+			"content_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSiteAssetCreate(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	meta := metaRaw.(*Meta)
+	source := d.Get("source").(string)
+	file, err := os.Open(source)
+	if err != nil {
+		return apiErrorDiags(err, nil)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return apiErrorDiags(err, nil)
+	}
+
+	checksum, err := fileChecksum(source)
+	if err != nil {
+		return apiErrorDiags(err, nil)
+	}
+
+	name := d.Get("name").(string)
+	if name == "" {
+		name = filepath.Base(source)
+	}
+
+	asset, err := meta.Netlify.UploadNewSiteAsset(meta.porcelainContext(c), &porcelain.SiteAsset{
+		SiteID:  d.Get("site_id").(string),
+		Name:    name,
+		Size:    info.Size(),
+		Private: d.Get("visibility").(string) == "private",
+		Body:    file,
+	})
+	if err != nil {
+		return apiErrorDiags(err, cty.GetAttrPath("site_id"))
+	}
+
+	d.SetId(asset.ID)
+	d.Set("checksum", checksum)
+	return resourceSiteAssetRead(c, d, metaRaw)
+}
+
+func resourceSiteAssetRead(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	meta := metaRaw.(*Meta)
+	params := operations.NewGetSiteAssetInfoParamsWithContext(c)
+	params.SiteID = d.Get("site_id").(string)
+	params.AssetID = d.Id()
+	resp, err := meta.Netlify.Operations.GetSiteAssetInfo(params, meta.AuthInfo)
+	if err != nil {
+		// If it is a 404 it was removed remotely
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+
+		return apiErrorDiags(err, nil)
+	}
+
+	asset := resp.Payload
+	d.Set("name", asset.Name)
+	d.Set("visibility", asset.Visibility)
+	d.Set("url", asset.URL)
+	d.Set("content_type", asset.ContentType)
+	d.Set("size", int(asset.Size))
+	d.Set("public_signature", "")
+
+	if asset.Visibility == "private" {
+		sigParams := operations.NewGetSiteAssetPublicSignatureParamsWithContext(c)
+		sigParams.SiteID = params.SiteID
+		sigParams.AssetID = params.AssetID
+		sig, err := meta.Netlify.GetSiteAssetPublicSignature(meta.porcelainContext(c), sigParams)
+		if err != nil {
+			return apiErrorDiags(err, nil)
+		}
+		d.Set("public_signature", sig.URL)
+	}
+
+	return nil
+}
+
+func resourceSiteAssetDelete(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	meta := metaRaw.(*Meta)
+	params := operations.NewDeleteSiteAssetParamsWithContext(c)
+	params.SiteID = d.Get("site_id").(string)
+	params.AssetID = d.Id()
+	_, err := meta.Netlify.Operations.DeleteSiteAsset(params, meta.AuthInfo)
+	return apiErrorDiags(err, nil)
+}
+
+// Replaces the asset when the checksum of the local file no longer matches
+// the uploaded one.
+func resourceSiteAssetCustomizeDiff(c context.Context, d *schema.ResourceDiff, metaRaw interface{}) error {
+	if d.Id() == "" || !d.NewValueKnown("source") {
+		return nil
+	}
+
+	checksum, err := fileChecksum(d.Get("source").(string))
+	if err != nil {
+		return err
+	}
+
+	if checksum != d.Get("checksum").(string) {
+		if err := d.SetNew("checksum", checksum); err != nil {
+			return err
+		}
+		return d.ForceNew("checksum")
+	}
+
+	return nil
+}
+
+// Returns the hex encoded SHA256 checksum of a file.
+func fileChecksum(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("Error reading %s: %s", path, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,70 @@
+package netlify
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccSiteAsset(t *testing.T) {
+	testAccSkipFake(t)
+
+	resourceName := "netlify_site_asset.test"
+	source := filepath.Join(t.TempDir(), "asset.txt")
+	var firstID string
+
+	writeSource := func(contents string) func() {
+		return func() {
+			if err := os.WriteFile(source, []byte(contents), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckSiteDestroy,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: writeSource("first"),
+				Config:    fmt.Sprintf(testAccSiteAssetConfig, source),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "asset.txt"),
+					resource.TestCheckResourceAttrSet(resourceName, "public_signature"),
+					func(s *terraform.State) error {
+						firstID = s.RootModule().Resources[resourceName].Primary.ID
+						return nil
+					},
+				),
+			},
+
+			{
+				PreConfig: writeSource("second"),
+				Config:    fmt.Sprintf(testAccSiteAssetConfig, source),
+				Check: resource.ComposeTestCheckFunc(
+					func(s *terraform.State) error {
+						if s.RootModule().Resources[resourceName].Primary.ID == firstID {
+							return fmt.Errorf("asset was not uploaded again")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+var testAccSiteAssetConfig = `
+resource "netlify_site" "test" {}
+
+resource "netlify_site_asset" "test" {
+	site_id = netlify_site.test.id
+	source = "%s"
+	visibility = "private"
+}
+`
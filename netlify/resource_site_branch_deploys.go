@@ -0,0 +1,235 @@
+package netlify
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/netlify/open-api/v2/go/models"
+	"github.com/netlify/open-api/v2/go/plumbing/operations"
+)
+
+// Branch deploy modes. Netlify deploys every branch when no branches are
+// allowed, and only the production branch when it is the only one allowed.
+const (
+	branchDeploysAll    = "all"
+	branchDeploysNone   = "none"
+	branchDeploysListed = "listed"
+)
+
+func resourceSiteBranchDeploys() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages which branches of a site's repository are deployed. Unlike `netlify_branch_deploy`, this manages the whole list of branches at once and should not be combined with it. Destroying this resource deploys all branches again, which is the default.",
+		CreateContext: resourceSiteBranchDeploysCreate,
+		ReadContext:   resourceSiteBranchDeploysRead,
+		UpdateContext: resourceSiteBranchDeploysUpdate,
+		DeleteContext: resourceSiteBranchDeploysDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: resourceSiteBranchDeploysCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"site_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the site. The site must be linked to a repository.",
+				Required:    true,
+				ForceNew:    true,
+			},
+
+			"mode": {
+				Type:         schema.TypeString,
+				Description:  "Which branches to deploy besides the production branch: `all`, `none` or `listed`.",
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{branchDeploysAll, branchDeploysNone, branchDeploysListed}, false),
+			},
+
+			"branches": {
+				Type:        schema.TypeSet,
+				Description: "The branches to deploy when `mode` is `listed`.",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceSiteBranchDeploysCreate(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	if err := resourceSiteBranchDeploys_update(c, metaRaw.(*Meta), d); err != nil {
+		return apiErrorDiags(err, cty.GetAttrPath("site_id"))
+	}
+
+	d.SetId(d.Get("site_id").(string))
+	return resourceSiteBranchDeploysRead(c, d, metaRaw)
+}
+
+func resourceSiteBranchDeploysRead(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	meta := metaRaw.(*Meta)
+	params := operations.NewGetSiteParamsWithContext(c)
+	params.SiteID = d.Id()
+	resp, err := meta.Netlify.Operations.GetSite(params, meta.AuthInfo)
+	if err != nil {
+		// If it is a 404 the site was removed remotely
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+
+		return apiErrorDiags(err, nil)
+	}
+
+	mode, branches := branchDeploysFromRepoInfo(resp.Payload.BuildSettings)
+	d.Set("site_id", d.Id())
+	d.Set("mode", mode)
+	d.Set("branches", branches)
+
+	return nil
+}
+
+func resourceSiteBranchDeploysUpdate(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	if err := resourceSiteBranchDeploys_update(c, metaRaw.(*Meta), d); err != nil {
+		return apiErrorDiags(err, cty.GetAttrPath("site_id"))
+	}
+
+	return resourceSiteBranchDeploysRead(c, d, metaRaw)
+}
+
+func resourceSiteBranchDeploysDelete(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	meta := metaRaw.(*Meta)
+	siteID := d.Get("site_id").(string)
+
+	meta.SiteLocks.Lock(siteID)
+	defer meta.SiteLocks.Unlock(siteID)
+
+	// No allowed branches is the default of deploying all of them.
+	err := resourceSiteBranchDeploys_setAllowedBranches(c, meta, siteID, []string{})
+	if isNotFound(err) {
+		return nil
+	}
+	return apiErrorDiags(err, nil)
+}
+
+// Branches can only be listed in the listed mode, which requires at least one
+// besides the production branch. Listing only the production branch is the
+// none mode, which would be read back and never match the configuration.
+func resourceSiteBranchDeploysCustomizeDiff(c context.Context, d *schema.ResourceDiff, metaRaw interface{}) error {
+	if !d.NewValueKnown("mode") || !d.NewValueKnown("branches") {
+		return nil
+	}
+
+	mode := d.Get("mode").(string)
+	branches := d.Get("branches").(*schema.Set)
+	if mode == branchDeploysListed && branches.Len() == 0 {
+		return fmt.Errorf("branches must be set when mode is %q", branchDeploysListed)
+	}
+	if mode != branchDeploysListed && branches.Len() > 0 {
+		return fmt.Errorf("branches can only be set when mode is %q", branchDeploysListed)
+	}
+
+	// The production branch is only known once the site exists.
+	if mode != branchDeploysListed || !d.NewValueKnown("site_id") {
+		return nil
+	}
+	repoBranch, err := resourceSiteBranchDeploys_repoBranch(c, metaRaw.(*Meta), d.Get("site_id").(string))
+	if err != nil {
+		return err
+	}
+	if branches.Len() == 1 && branches.Contains(repoBranch) {
+		return fmt.Errorf("branches must include a branch other than the production branch %q, or set mode to %q to only deploy it", repoBranch, branchDeploysNone)
+	}
+
+	return nil
+}
+
+// Replaces the allowed branches of the site with the configured ones.
+func resourceSiteBranchDeploys_update(c context.Context, meta *Meta, d *schema.ResourceData) error {
+	siteID := d.Get("site_id").(string)
+
+	meta.SiteLocks.Lock(siteID)
+	defer meta.SiteLocks.Unlock(siteID)
+
+	repoBranch, err := resourceSiteBranchDeploys_repoBranch(c, meta, siteID)
+	if err != nil {
+		return err
+	}
+
+	allowed := []string{}
+	switch d.Get("mode").(string) {
+	case branchDeploysNone:
+		allowed = append(allowed, repoBranch)
+	case branchDeploysListed:
+		allowed = append(allowed, repoBranch)
+		for _, b := range d.Get("branches").(*schema.Set).List() {
+			if b.(string) != repoBranch {
+				allowed = append(allowed, b.(string))
+			}
+		}
+	}
+
+	return resourceSiteBranchDeploys_setAllowedBranches(c, meta, siteID, allowed)
+}
+
+// Returns the production branch of a site, failing if it has no repository.
+func resourceSiteBranchDeploys_repoBranch(c context.Context, meta *Meta, siteID string) (string, error) {
+	params := operations.NewGetSiteParamsWithContext(c)
+	params.SiteID = siteID
+	resp, err := meta.Netlify.Operations.GetSite(params, meta.AuthInfo)
+	if err != nil {
+		return "", err
+	}
+
+	settings := resp.Payload.BuildSettings
+	if settings == nil || settings.RepoBranch == "" {
+		return "", fmt.Errorf("Site %s is not linked to a repository, so it has no branch deploys", siteID)
+	}
+	return settings.RepoBranch, nil
+}
+
+func resourceSiteBranchDeploys_setAllowedBranches(c context.Context, meta *Meta, siteID string, allowed []string) error {
+	params := operations.NewUpdateSiteParamsWithContext(c)
+	params.SiteID = siteID
+	params.Site = &models.SiteSetup{
+		Site: models.Site{
+			BuildSettings: &models.RepoInfo{
+				AllowedBranches: allowed,
+			},
+		},
+	}
+	_, err := meta.Netlify.Operations.UpdateSite(params, meta.AuthInfo)
+	return err
+}
+
+// Returns the branch deploy mode and the deployed non-production branches of
+// a site's build settings.
+func branchDeploysFromRepoInfo(settings *models.RepoInfo) (string, []string) {
+	if settings == nil || len(settings.AllowedBranches) == 0 {
+		return branchDeploysAll, nil
+	}
+
+	var branches []string
+	for _, b := range settings.AllowedBranches {
+		if b != settings.RepoBranch {
+			branches = append(branches, b)
+		}
+	}
+	if len(branches) == 0 {
+		return branchDeploysNone, nil
+	}
+
+	sort.Strings(branches)
+	return branchDeploysListed, branches
+}
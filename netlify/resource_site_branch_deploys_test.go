@@ -0,0 +1,162 @@
+package netlify
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/netlify/open-api/v2/go/models"
+)
+
+func TestAccSiteBranchDeploys(t *testing.T) {
+	var site models.Site
+	resourceName := "netlify_site_branch_deploys.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckSiteDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSiteBranchDeploysConfig_listed,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "mode", "listed"),
+					resource.TestCheckResourceAttr(resourceName, "branches.#", "2"),
+				),
+			},
+			{
+				Config: testAccSiteBranchDeploysConfig_all,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "mode", "all"),
+					resource.TestCheckResourceAttr(resourceName, "branches.#", "0"),
+				),
+			},
+			{
+				Config: testAccSiteBranchDeploysConfig_none,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "mode", "none"),
+					resource.TestCheckResourceAttr(resourceName, "branches.#", "0"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:      testAccSiteBranchDeploysConfig_productionOnly,
+				ExpectError: regexp.MustCompile(`branch other than the production branch "master"`),
+			},
+			// Destroying the resource deploys all branches again.
+			{
+				Config: testAccSiteBranchDeploysConfig_destroyed,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSiteExists("netlify_site.test", &site),
+					testAccAssert("deploys all branches", func() bool {
+						mode, _ := branchDeploysFromRepoInfo(site.BuildSettings)
+						return mode == branchDeploysAll
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestBranchDeploysFromRepoInfo(t *testing.T) {
+	cases := []struct {
+		name         string
+		settings     *models.RepoInfo
+		wantMode     string
+		wantBranches []string
+	}{
+		{"no repo", nil, "all", nil},
+		{"no branches", &models.RepoInfo{RepoBranch: "main"}, "all", nil},
+		{"production only", &models.RepoInfo{RepoBranch: "main", AllowedBranches: []string{"main"}}, "none", nil},
+		{"listed", &models.RepoInfo{RepoBranch: "main", AllowedBranches: []string{"main", "staging", "dev"}}, "listed", []string{"dev", "staging"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mode, branches := branchDeploysFromRepoInfo(tc.settings)
+			if mode != tc.wantMode {
+				t.Errorf("expected mode %q, got %q", tc.wantMode, mode)
+			}
+			if !reflect.DeepEqual(branches, tc.wantBranches) {
+				t.Errorf("expected branches %v, got %v", tc.wantBranches, branches)
+			}
+		})
+	}
+}
+
+var testAccSiteBranchDeploysConfig_listed = `
+resource "netlify_site" "test" {
+	repo {
+		provider = "github"
+		repo_path = "mitchellh/fogli"
+		repo_branch = "master"
+	}
+}
+
+resource "netlify_site_branch_deploys" "test" {
+	site_id = netlify_site.test.id
+	mode = "listed"
+	branches = ["staging", "dev"]
+}
+`
+
+var testAccSiteBranchDeploysConfig_all = `
+resource "netlify_site" "test" {
+	repo {
+		provider = "github"
+		repo_path = "mitchellh/fogli"
+		repo_branch = "master"
+	}
+}
+
+resource "netlify_site_branch_deploys" "test" {
+	site_id = netlify_site.test.id
+	mode = "all"
+}
+`
+
+var testAccSiteBranchDeploysConfig_none = `
+resource "netlify_site" "test" {
+	repo {
+		provider = "github"
+		repo_path = "mitchellh/fogli"
+		repo_branch = "master"
+	}
+}
+
+resource "netlify_site_branch_deploys" "test" {
+	site_id = netlify_site.test.id
+	mode = "none"
+}
+`
+
+var testAccSiteBranchDeploysConfig_productionOnly = `
+resource "netlify_site" "test" {
+	repo {
+		provider = "github"
+		repo_path = "mitchellh/fogli"
+		repo_branch = "master"
+	}
+}
+
+resource "netlify_site_branch_deploys" "test" {
+	site_id = netlify_site.test.id
+	mode = "listed"
+	branches = ["master"]
+}
+`
+
+var testAccSiteBranchDeploysConfig_destroyed = `
+resource "netlify_site" "test" {
+	repo {
+		provider = "github"
+		repo_path = "mitchellh/fogli"
+		repo_branch = "master"
+	}
+}
+`
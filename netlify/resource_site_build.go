@@ -0,0 +1,174 @@
+package netlify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/netlify/open-api/v2/go/models"
+	"github.com/netlify/open-api/v2/go/plumbing/operations"
+)
+
+func resourceSiteBuild() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Triggers a build of a site and waits for it to finish. Any change to the arguments, including `triggers`, starts a new build.",
+		CreateContext: resourceSiteBuildCreate,
+		ReadContext:   resourceSiteBuildRead,
+		DeleteContext: resourceSiteBuildDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"site_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the site to build.",
+				Required:    true,
+				ForceNew:    true,
+			},
+
+			"clear_cache": {
+				Type:        schema.TypeBool,
+				Description: "Whether to clear the build cache before building.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+
+			"image": {
+				Type:        schema.TypeString,
+				Description: "The build image to use for this build.",
+				Optional:    true,
+				ForceNew:    true,
+			},
+
+			"triggers": {
+				Type:        schema.TypeMap,
+				Description: "Arbitrary map of values that, when changed, will trigger a new build.",
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+
+			"deploy_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the deploy produced by the build.",
+				Computed:    true,
+			},
+
+			"sha": {
+				Type:        schema.TypeString,
+				Description: "The commit SHA that was built.",
+				Computed:    true,
+			},
+
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSiteBuildCreate(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	meta := metaRaw.(*Meta)
+	params := operations.NewCreateSiteBuildParamsWithContext(c)
+	params.SiteID = d.Get("site_id").(string)
+	params.Build = &models.BuildSetup{
+		ClearCache: d.Get("clear_cache").(bool),
+		Image:      d.Get("image").(string),
+	}
+
+	resp, err := meta.Netlify.Operations.CreateSiteBuild(params, meta.AuthInfo)
+	if err != nil {
+		return apiErrorDiags(err, cty.GetAttrPath("site_id"))
+	}
+
+	d.SetId(resp.Payload.ID)
+
+	build, err := resourceSiteBuild_waitUntilDone(c, meta, d.Id(), d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return apiErrorDiags(err, nil)
+	}
+	if build.Error != "" {
+		// The build already exists remotely, but a failed build should
+		// not be recorded in the state so the next apply tries again.
+		d.SetId("")
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Site build failed.",
+				Detail:   fmt.Sprintf("Build %s of site %s failed: %s", build.ID, params.SiteID, build.Error),
+			},
+		}
+	}
+
+	return resourceSiteBuildRead(c, d, metaRaw)
+}
+
+func resourceSiteBuildRead(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	meta := metaRaw.(*Meta)
+	params := operations.NewGetSiteBuildParamsWithContext(c)
+	params.BuildID = d.Id()
+	resp, err := meta.Netlify.Operations.GetSiteBuild(params, meta.AuthInfo)
+	if err != nil {
+		// If it is a 404 it was removed remotely
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+
+		return apiErrorDiags(err, nil)
+	}
+
+	build := resp.Payload
+	d.Set("deploy_id", build.DeployID)
+	d.Set("sha", build.Sha)
+	d.Set("created_at", build.CreatedAt)
+
+	return nil
+}
+
+// A finished build stays in the site's deploy history.
+func resourceSiteBuildDelete(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+// Polls the build until Netlify reports it as done, returning the finished build.
+func resourceSiteBuild_waitUntilDone(c context.Context, meta *Meta, buildID string, timeout time.Duration) (*models.Build, error) {
+	conf := &resource.StateChangeConf{
+		Pending: []string{"building"},
+		Target:  []string{"done"},
+		Refresh: func() (interface{}, string, error) {
+			params := operations.NewGetSiteBuildParamsWithContext(c)
+			params.BuildID = buildID
+			resp, err := meta.Netlify.Operations.GetSiteBuild(params, meta.AuthInfo)
+			if err != nil {
+				return nil, "", err
+			}
+
+			if resp.Payload.Done {
+				return resp.Payload, "done", nil
+			}
+			return resp.Payload, "building", nil
+		},
+		Timeout:    timeout,
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	build, err := conf.WaitForStateContext(c)
+	if err != nil {
+		return nil, fmt.Errorf("Error waiting for build %s to finish: %s", buildID, err)
+	}
+
+	return build.(*models.Build), nil
+}
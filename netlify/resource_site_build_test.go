@@ -0,0 +1,108 @@
+package netlify
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/netlify/open-api/v2/go/models"
+	"github.com/netlify/open-api/v2/go/plumbing/operations"
+)
+
+func TestAccSiteBuild_basic(t *testing.T) {
+	testAccSkipFake(t)
+
+	var build models.Build
+	resourceName := "netlify_site_build.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckSiteDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccSiteBuildConfig, "one"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSiteBuildExists(resourceName, &build),
+					testAccAssert("build is done", func() bool {
+						return build.Done && build.Error == ""
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSiteBuild_triggers(t *testing.T) {
+	testAccSkipFake(t)
+
+	var first, second models.Build
+	resourceName := "netlify_site_build.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckSiteDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccSiteBuildConfig, "one"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSiteBuildExists(resourceName, &first),
+				),
+			},
+
+			{
+				Config: fmt.Sprintf(testAccSiteBuildConfig, "two"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSiteBuildExists(resourceName, &second),
+					testAccAssert("started a new build", func() bool {
+						return first.ID != second.ID
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckSiteBuildExists(n string, build *models.Build) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not Found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No build ID is set")
+		}
+
+		meta := testAccProvider.Meta().(*Meta)
+		params := operations.NewGetSiteBuildParams()
+		params.BuildID = rs.Primary.ID
+		resp, err := meta.Netlify.Operations.GetSiteBuild(params, meta.AuthInfo)
+		if err != nil {
+			return err
+		}
+
+		*build = *resp.Payload
+		return nil
+	}
+}
+
+var testAccSiteBuildConfig = `
+resource "netlify_site" "test" {
+	repo {
+		provider = "github"
+		repo_path = "mitchellh/fogli"
+		repo_branch = "master"
+	}
+}
+
+resource "netlify_site_build" "test" {
+	site_id = "${netlify_site.test.id}"
+
+	triggers = {
+		version = "%s"
+	}
+}
+`
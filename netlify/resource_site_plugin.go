@@ -0,0 +1,131 @@
+package netlify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/netlify/open-api/v2/go/models"
+	"github.com/netlify/open-api/v2/go/plumbing/operations"
+)
+
+func resourceSitePlugin() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Pins the version of a build plugin installed on a site. The API offers no way to read plugin settings, so changes made outside of Terraform are not detected.",
+		CreateContext: resourceSitePluginCreateOrUpdate,
+		ReadContext:   resourceSitePluginRead,
+		UpdateContext: resourceSitePluginCreateOrUpdate,
+		DeleteContext: resourceSitePluginDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Read:   schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"site_id": {
+				Type:        schema.TypeString,
+				Description: "The ID of the site the plugin is installed on.",
+				Required:    true,
+				ForceNew:    true,
+			},
+
+			"package": {
+				Type:        schema.TypeString,
+				Description: "The npm package name of the plugin, e.g. `@netlify/plugin-lighthouse`.",
+				Required:    true,
+				ForceNew:    true,
+			},
+
+			"pinned_version": {
+				Type:        schema.TypeString,
+				Description: "The major version of the plugin to pin, e.g. `4`.",
+				Required:    true,
+			},
+		},
+	}
+}
+
+func resourceSitePluginCreateOrUpdate(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	meta := metaRaw.(*Meta)
+	params := operations.NewUpdatePluginParamsWithContext(c)
+	params.SiteID = d.Get("site_id").(string)
+	params.Package = d.Get("package").(string)
+	params.PluginParams = &models.PluginParams{
+		PinnedVersion: d.Get("pinned_version").(string),
+	}
+
+	resp, err := meta.Netlify.Operations.UpdatePlugin(params, meta.AuthInfo)
+	if err != nil {
+		return apiErrorDiags(err, cty.GetAttrPath("site_id"))
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", params.SiteID, params.Package))
+	if resp.Payload.PinnedVersion != "" {
+		d.Set("pinned_version", resp.Payload.PinnedVersion)
+	}
+
+	return resourceSitePluginRead(c, d, metaRaw)
+}
+
+// There is no endpoint to read the plugins of a site, so this only restores
+// the site ID and package from the resource ID.
+func resourceSitePluginRead(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	split := strings.SplitN(d.Id(), "/", 2)
+	if len(split) != 2 {
+		return diag.Errorf("Invalid site plugin ID %q, expected <site_id>/<package>", d.Id())
+	}
+
+	d.Set("site_id", split[0])
+	d.Set("package", split[1])
+	return nil
+}
+
+// Deleting unpins the plugin version; the plugin itself stays installed.
+func resourceSitePluginDelete(c context.Context, d *schema.ResourceData, metaRaw interface{}) diag.Diagnostics {
+	meta := metaRaw.(*Meta)
+	_, err := meta.Netlify.Transport.Submit(&runtime.ClientOperation{
+		ID:                 "updatePlugin",
+		Method:             "PUT",
+		PathPattern:        "/sites/{site_id}/plugins/{package}",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"https"},
+		Params: &unpinPluginParams{
+			SiteID:  d.Get("site_id").(string),
+			Package: d.Get("package").(string),
+		},
+		Reader:   &operations.UpdatePluginReader{},
+		AuthInfo: meta.AuthInfo,
+		Context:  c,
+	})
+	if isNotFound(err) {
+		return nil
+	}
+	return apiErrorDiags(err, nil)
+}
+
+// PluginParams omits an empty pinned_version, which would leave the version
+// pinned, so unpinning sends an explicit null instead.
+type unpinPluginParams struct {
+	SiteID  string
+	Package string
+}
+
+func (p *unpinPluginParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+	if err := r.SetBodyParam(map[string]interface{}{"pinned_version": nil}); err != nil {
+		return err
+	}
+	if err := r.SetPathParam("site_id", p.SiteID); err != nil {
+		return err
+	}
+	return r.SetPathParam("package", p.Package)
+}
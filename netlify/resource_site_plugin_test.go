@@ -0,0 +1,75 @@
+package netlify
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestSitePluginDelete(t *testing.T) {
+	var method, path, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		method, path, body = r.Method, r.URL.Path, string(b)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"package": "netlify-plugin-a11y"}`))
+	}))
+	defer server.Close()
+
+	config := Config{Token: "token", BaseURL: server.URL}
+	meta, err := config.Client(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := resourceSitePlugin()
+	d := r.TestResourceData()
+	d.Set("site_id", "site")
+	d.Set("package", "netlify-plugin-a11y")
+	d.Set("pinned_version", "1")
+	if diags := r.DeleteContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected diagnostics %v", diags)
+	}
+
+	if method != http.MethodPut || path != "/sites/site/plugins/netlify-plugin-a11y" {
+		t.Errorf("unexpected request %s %s", method, path)
+	}
+	if body != `{"pinned_version":null}`+"\n" {
+		t.Errorf("expected the body to unpin the version, got %q", body)
+	}
+}
+
+func TestAccSitePlugin(t *testing.T) {
+	testAccSkipFake(t)
+
+	resourceName := "netlify_site_plugin.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckSiteDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSitePluginConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "package", "@netlify/plugin-lighthouse"),
+					resource.TestCheckResourceAttr(resourceName, "pinned_version", "4"),
+				),
+			},
+		},
+	})
+}
+
+var testAccSitePluginConfig = `
+resource "netlify_site" "test" {}
+
+resource "netlify_site_plugin" "test" {
+	site_id = netlify_site.test.id
+	package = "@netlify/plugin-lighthouse"
+	pinned_version = "4"
+}
+`
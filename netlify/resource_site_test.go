@@ -1,23 +1,115 @@
 package netlify
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/netlify/open-api/v2/go/models"
 	"github.com/netlify/open-api/v2/go/plumbing/operations"
 )
 
+func TestSiteResource_upgradeStateV0(t *testing.T) {
+	attributes := testUpgradeResourceState(t, "netlify_site", 0, `{
+		"id": "site-id",
+		"name": "site-name",
+		"custom_domain": "",
+		"deploy_url": "https://site-name.netlify.app",
+		"account_slug": "team",
+		"account_name": "Team",
+		"wait_for_deploy": false,
+		"repo": [{
+			"command": "npm run build",
+			"deploy_key_id": "",
+			"dir": "public",
+			"provider": "github",
+			"repo_path": "owner/repo",
+			"repo_branch": "main",
+			"installation_id": 42
+		}],
+		"timeouts": null
+	}`)
+
+	for _, name := range []string{"custom_domain", "wait_for_deploy"} {
+		if !attributes[name].IsNull() {
+			t.Errorf("expected %s to be null, got %s", name, attributes[name])
+		}
+	}
+
+	var repo map[string]tftypes.Value
+	if err := attributes["repo"].As(&repo); err != nil {
+		t.Fatal(err)
+	}
+	if !repo["deploy_key_id"].IsNull() {
+		t.Errorf("expected repo.deploy_key_id to be null, got %s", repo["deploy_key_id"])
+	}
+	if !repo["repo_path"].Equal(tftypes.NewValue(tftypes.String, "owner/repo")) {
+		t.Errorf("unexpected repo.repo_path %s", repo["repo_path"])
+	}
+}
+
+func TestFindSiteByName(t *testing.T) {
+	// A full page of sites whose names contain the name, with the site itself
+	// on the second page.
+	var sites []*models.Site
+	for i := 0; i < sitesPerPage; i++ {
+		sites = append(sites, &models.Site{ID: fmt.Sprint(i), Name: fmt.Sprintf("site-%d", i)})
+	}
+	sites = append(sites, &models.Site{ID: "site", Name: "site"})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		matches := []*models.Site{}
+		for _, s := range sites {
+			if strings.Contains(s.Name, r.URL.Query().Get("name")) {
+				matches = append(matches, s)
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(fakePage(r, matches))
+	}))
+	defer server.Close()
+
+	config := Config{Token: "token", BaseURL: server.URL}
+	client, err := config.Client(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := client.(*Meta)
+
+	site, err := findSiteByName(context.Background(), meta, "site")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if site == nil || site.ID != "site" {
+		t.Fatalf("expected the site on the second page, got %+v", site)
+	}
+
+	site, err = findSiteByName(context.Background(), meta, "missing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if site != nil {
+		t.Fatalf("expected no site, got %+v", site)
+	}
+}
+
 func TestAccSite_basic(t *testing.T) {
 	var site models.Site
 	resourceName := "netlify_site.test"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
-		CheckDestroy:      testAccCheckSiteDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckSiteDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccSiteConfig_repo,
@@ -29,6 +121,28 @@ func TestAccSite_basic(t *testing.T) {
 	})
 }
 
+func TestAccSite_waitForDeploy(t *testing.T) {
+	var site models.Site
+	resourceName := "netlify_site.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckSiteDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSiteConfig_waitForDeploy,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSiteExists(resourceName, &site),
+					testAccAssert("has a published deploy", func() bool {
+						return site.PublishedDeploy != nil && site.PublishedDeploy.State == "ready"
+					}),
+				),
+			},
+		},
+	})
+}
+
 func TestAccSite_disappears(t *testing.T) {
 	var site models.Site
 
@@ -41,9 +155,9 @@ func TestAccSite_disappears(t *testing.T) {
 	}
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
-		CheckDestroy:      testAccCheckSiteDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckSiteDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccSiteConfig,
@@ -63,9 +177,9 @@ func TestAccSite_updateName(t *testing.T) {
 	siteName := fmt.Sprintf("test-%s", "new-name")
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
-		CheckDestroy:      testAccCheckSiteDestroy,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckSiteDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccSiteConfig,
@@ -90,6 +204,129 @@ func TestAccSite_updateName(t *testing.T) {
 	})
 }
 
+func TestAccSite_removeRepo(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckSiteDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSiteConfig_repo,
+			},
+			{
+				Config:      testAccSiteConfig,
+				ExpectError: regexp.MustCompile("Cannot remove repo"),
+			},
+		},
+	})
+}
+
+func TestAccSite_importByName(t *testing.T) {
+	siteName := fmt.Sprintf("tf-test-%s", RandStringBytes(6))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckSiteDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccSiteConfig_updateName, siteName),
+			},
+			{
+				ResourceName:      "netlify_site.test",
+				ImportState:       true,
+				ImportStateId:     siteName,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccSite_importBlock(t *testing.T) {
+	testAccRequireTerraformVersion(t, "1.5.0")
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set", resource.EnvTfAcc)
+	}
+	testAccPreCheck(t)
+
+	var site models.Site
+	siteName := fmt.Sprintf("tf-test-%s", RandStringBytes(6))
+
+	// The site is created outside of Terraform, for the import block to
+	// import it by name. Its configuration is generated before the steps
+	// run, as they cannot change their configuration once started.
+	meta := testAccMeta(t)
+	params := operations.NewCreateSiteParams()
+	params.Site = &models.SiteSetup{Site: models.Site{Name: siteName}}
+	created, err := meta.Netlify.Operations.CreateSite(params, meta.AuthInfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The steps destroy the imported site, but it is orphaned if the test
+	// stops before they run.
+	t.Cleanup(func() {
+		params := operations.NewDeleteSiteParams()
+		params.SiteID = created.Payload.ID
+		if _, err := meta.Netlify.Operations.DeleteSite(params, meta.AuthInfo); err != nil && !isNotFound(err) {
+			t.Errorf("deleting site %s: %s", siteName, err)
+		}
+	})
+	generated := testAccGenerateConfig(t, fmt.Sprintf(testAccSiteConfig_import, siteName))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckSiteDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccSiteConfig_import+testAccSiteConfig_updateName, siteName, siteName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSiteExists("netlify_site.test", &site),
+					testAccAssert("imported the site", func() bool {
+						return site.Name == siteName
+					}),
+				),
+			},
+			// The generated configuration matches the imported site, so it
+			// plans no changes.
+			{
+				Config: fmt.Sprintf(testAccSiteConfig_import, siteName) + generated,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSiteExists("netlify_site.test", &site),
+					resource.TestCheckResourceAttr("netlify_site.test", "name", siteName),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSite_faults(t *testing.T) {
+	testAccRequireFake(t)
+
+	var site models.Site
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccFakeAPI.injectFault("POST", "/sites", 429, 1)
+			testAccFakeAPI.injectFault("GET", "/sites/", 503, 2)
+		},
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		CheckDestroy:             testAccCheckSiteDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSiteConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSiteExists("netlify_site.test", &site),
+					testAccAssert("retried all faults", func() bool {
+						return testAccFakeAPI.pendingFaults() == 0
+					}),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckSiteExists(n string, site *models.Site) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -129,7 +366,7 @@ func testAccCheckSiteDestroy(s *terraform.State) error {
 		}
 
 		if err != nil {
-			if v, ok := err.(*operations.GetSiteDefault); ok && v.Code() == 404 {
+			if isNotFound(err) {
 				return nil
 			}
 		}
@@ -154,8 +391,27 @@ resource "netlify_site" "test" {
 }
 `
 
+var testAccSiteConfig_waitForDeploy = `
+resource "netlify_site" "test" {
+	wait_for_deploy = true
+
+	repo {
+		provider = "github"
+		repo_path = "mitchellh/fogli"
+		repo_branch = "master"
+	}
+}
+`
+
 var testAccSiteConfig_updateName = `
 resource "netlify_site" "test" {
 	name = "%s"
 }
 `
+
+var testAccSiteConfig_import = `
+import {
+	to = netlify_site.test
+	id = "%s"
+}
+`
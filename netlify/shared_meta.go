@@ -0,0 +1,42 @@
+package netlify
+
+import (
+	"context"
+	"sync"
+)
+
+// sharedMeta is shared by the muxed providers, which Terraform configures
+// with the same configuration. The first provider to be configured creates
+// the client and the other reuses it, so the credentials and the default
+// account are only checked once.
+type sharedMeta struct {
+	siteLocks *siteLocks
+
+	mu     sync.Mutex
+	config Config
+	meta   *Meta
+}
+
+func newSharedMeta() *sharedMeta {
+	return &sharedMeta{siteLocks: newSiteLocks()}
+}
+
+// Returns the client for a provider configuration, reusing the last one if
+// it was configured the same way. Errors are not kept, as the mux does not
+// configure the other provider once one has failed.
+func (s *sharedMeta) configure(c context.Context, config Config) (*Meta, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	config.SiteLocks = s.siteLocks
+	if s.meta != nil && s.config == config {
+		return s.meta, nil
+	}
+
+	meta, err := configureMeta(c, config)
+	if err != nil {
+		return nil, err
+	}
+	s.config, s.meta = config, meta
+	return meta, nil
+}
@@ -0,0 +1,48 @@
+package netlify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSharedMeta(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": "user"})
+	}))
+	defer server.Close()
+
+	shared := newSharedMeta()
+	config := Config{Token: "token", BaseURL: server.URL}
+
+	// The second provider reuses the client of the first.
+	first, err := shared.configure(context.Background(), config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := shared.configure(context.Background(), config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second || requests != 1 {
+		t.Fatalf("expected the client to be configured once, got %d requests", requests)
+	}
+	if first.SiteLocks != shared.siteLocks {
+		t.Fatal("expected the client to use the shared site locks")
+	}
+
+	// Another configuration gets its own client.
+	config.Token = "other"
+	third, err := shared.configure(context.Background(), config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if third == first || requests != 2 {
+		t.Fatalf("expected a new client for another configuration, got %d requests", requests)
+	}
+}
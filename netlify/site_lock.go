@@ -0,0 +1,40 @@
+package netlify
+
+import "sync"
+
+// siteLocks serialises changes to the settings of a site. Resources that read
+// the settings of a site and write back a modified copy, or that patch a part
+// of the settings another resource also manages, must hold the lock of the
+// site while doing so. Changes to different sites are not serialised.
+type siteLocks struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+func newSiteLocks() *siteLocks {
+	return &siteLocks{locks: map[string]*sync.Mutex{}}
+}
+
+// Lock locks the site, blocking until it is available.
+func (l *siteLocks) Lock(siteID string) {
+	l.get(siteID).Lock()
+}
+
+// Unlock unlocks the site.
+func (l *siteLocks) Unlock(siteID string) {
+	l.get(siteID).Unlock()
+}
+
+// Returns the lock of a site, creating it if necessary. Locks are never
+// removed, as there are only as many as the sites managed in one run.
+func (l *siteLocks) get(siteID string) *sync.Mutex {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	lock, ok := l.locks[siteID]
+	if !ok {
+		lock = &sync.Mutex{}
+		l.locks[siteID] = lock
+	}
+	return lock
+}
@@ -0,0 +1,44 @@
+package netlify
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSiteLocks(t *testing.T) {
+	locks := newSiteLocks()
+	locks.Lock("a")
+
+	// Other sites are not blocked.
+	done := make(chan struct{})
+	go func() {
+		locks.Lock("b")
+		locks.Unlock("b")
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("locking another site blocked")
+	}
+
+	// The same site is blocked until it is unlocked.
+	done = make(chan struct{})
+	go func() {
+		locks.Lock("a")
+		locks.Unlock("a")
+		close(done)
+	}()
+	select {
+	case <-done:
+		t.Fatal("locking a locked site did not block")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	locks.Unlock("a")
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("locking an unlocked site blocked")
+	}
+}
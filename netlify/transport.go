@@ -0,0 +1,246 @@
+package netlify
+
+import (
+	"context"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-openapi/runtime"
+)
+
+const (
+	// Once fewer requests than this remain in the rate limit window, the
+	// remaining requests are spread over the rest of the window.
+	rateLimitReserve = 10
+
+	// The delays between retries without a Retry-After header grow
+	// exponentially from the base delay up to the max delay.
+	retryBaseDelay = 1 * time.Second
+	retryMaxDelay  = 30 * time.Second
+)
+
+// rateLimitTransport is an http.RoundTripper that tracks Netlify's rate limit
+// headers, throttling requests before the limit is reached, and retries
+// requests that were rate limited or failed with a server error.
+type rateLimitTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+
+	mu sync.Mutex
+	// The number of requests left in the current window, or -1 if unknown.
+	remaining int
+	// When the current window ends.
+	reset time.Time
+}
+
+func newRateLimitTransport(next http.RoundTripper, maxRetries int) *rateLimitTransport {
+	return &rateLimitTransport{
+		next:       next,
+		maxRetries: maxRetries,
+		remaining:  -1,
+	}
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if err := sleepContext(req, t.throttleDelay(time.Now())); err != nil {
+			return nil, err
+		}
+
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := t.next.RoundTrip(req)
+		if err != nil {
+			return nil, err
+		}
+		t.update(resp.Header)
+
+		if attempt >= t.maxRetries || !shouldRetry(req, resp) {
+			return resp, nil
+		}
+
+		delay := retryDelay(resp, attempt, time.Now())
+		log.Printf("[DEBUG] Netlify responded %s to %s %s, retrying in %s", resp.Status, req.Method, req.URL.Path, delay)
+
+		// Drain the body so the connection can be reused.
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		if err := sleepContext(req, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Returns how long to wait before sending a request, and counts the request
+// against the rate limit.
+func (t *rateLimitTransport) throttleDelay(now time.Time) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.remaining < 0 || !now.Before(t.reset) {
+		return 0
+	}
+
+	if t.remaining == 0 {
+		return t.reset.Sub(now)
+	}
+
+	t.remaining--
+	if t.remaining+1 >= rateLimitReserve {
+		return 0
+	}
+	return t.reset.Sub(now) / time.Duration(t.remaining+2)
+}
+
+// Records the rate limit state reported by a response.
+func (t *rateLimitTransport) update(h http.Header) {
+	remaining, err := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	reset, ok := parseRateLimitReset(h)
+	if !ok {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.remaining = remaining
+	t.reset = reset
+}
+
+// Rate limited requests were not processed, so they are always retried.
+// Requests that failed with a server error are only retried if they are
+// idempotent, as they may have been processed.
+func shouldRetry(req *http.Request, resp *http.Response) bool {
+	if req.Body != nil && req.GetBody == nil {
+		return false
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		switch req.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+			return true
+		}
+	}
+	return false
+}
+
+// Returns how long to wait before retrying a request. The Retry-After header
+// is honoured if present, as is the rate limit reset time for rate limited
+// requests. Otherwise it backs off exponentially. Jitter is added to spread
+// out the retries of concurrent requests.
+func retryDelay(resp *http.Response, attempt int, now time.Time) time.Duration {
+	if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), now); ok {
+		return d + jitter(retryBaseDelay)
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if reset, ok := parseRateLimitReset(resp.Header); ok && reset.After(now) {
+			return reset.Sub(now) + jitter(retryBaseDelay)
+		}
+	}
+
+	backoff := retryMaxDelay
+	if attempt < 5 {
+		backoff = retryBaseDelay << attempt
+	}
+	if backoff > retryMaxDelay {
+		backoff = retryMaxDelay
+	}
+	return backoff/2 + jitter(backoff/2)
+}
+
+// Parses a Retry-After header, which is either a number of seconds or a date.
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(v); err == nil {
+		if date.Before(now) {
+			return 0, true
+		}
+		return date.Sub(now), true
+	}
+
+	return 0, false
+}
+
+// Parses the X-RateLimit-Reset header, a Unix timestamp.
+func parseRateLimitReset(h http.Header) (time.Time, bool) {
+	reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(reset, 0), true
+}
+
+// Returns a random duration between 0 and max.
+func jitter(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(max) + 1))
+}
+
+// Sleeps for the duration, returning early if the request is cancelled.
+func sleepContext(req *http.Request, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-req.Context().Done():
+		return req.Context().Err()
+	}
+}
+
+// timeoutTransport is a runtime.ClientTransport that limits how long API
+// operations may take, unless the timeout is zero. The limit is applied to the
+// operation's context, as the runtime ignores the timeout of operations that
+// have a context.
+type timeoutTransport struct {
+	next    runtime.ClientTransport
+	timeout time.Duration
+}
+
+func (t *timeoutTransport) Submit(op *runtime.ClientOperation) (interface{}, error) {
+	if t.timeout == 0 {
+		return t.next.Submit(op)
+	}
+
+	parent := op.Context
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(parent, t.timeout)
+	defer cancel()
+
+	op.Context = ctx
+	return t.next.Submit(op)
+}
@@ -0,0 +1,171 @@
+package netlify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/netlify/open-api/v2/go/plumbing/operations"
+)
+
+func TestRateLimitTransport_retries(t *testing.T) {
+	cases := []struct {
+		name      string
+		method    string
+		statuses  []int
+		wantCalls int
+		want      int
+	}{
+		{"success", "GET", []int{200}, 1, 200},
+		{"rate limited", "POST", []int{429, 429, 201}, 3, 201},
+		{"server error", "GET", []int{503, 200}, 2, 200},
+		{"server error not idempotent", "POST", []int{500, 201}, 1, 500},
+		{"client error", "GET", []int{404, 200}, 1, 404},
+		{"retries exhausted", "GET", []int{502, 502, 502, 502, 200}, 3, 502},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(tc.statuses[calls])
+				calls++
+			}))
+			defer server.Close()
+
+			client := &http.Client{Transport: newRateLimitTransport(http.DefaultTransport, 2)}
+			req, _ := http.NewRequest(tc.method, server.URL, strings.NewReader("{}"))
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tc.want {
+				t.Errorf("expected status %d, got %d", tc.want, resp.StatusCode)
+			}
+			if calls != tc.wantCalls {
+				t.Errorf("expected %d calls, got %d", tc.wantCalls, calls)
+			}
+		})
+	}
+}
+
+func TestRateLimitTransport_throttleDelay(t *testing.T) {
+	now := time.Now()
+	tr := newRateLimitTransport(nil, 0)
+	if d := tr.throttleDelay(now); d != 0 {
+		t.Errorf("expected no delay without rate limit headers, got %s", d)
+	}
+
+	tr.update(http.Header{
+		"X-Ratelimit-Remaining": {"100"},
+		"X-Ratelimit-Reset":     {"4000000000"},
+	})
+	if d := tr.throttleDelay(now); d != 0 {
+		t.Errorf("expected no delay with requests remaining, got %s", d)
+	}
+
+	tr.remaining = 1
+	tr.reset = now.Add(10 * time.Second)
+	if d := tr.throttleDelay(now); d != 5*time.Second {
+		t.Errorf("expected the last request to be delayed 5s, got %s", d)
+	}
+	if d := tr.throttleDelay(now); d != 10*time.Second {
+		t.Errorf("expected a delay until the reset, got %s", d)
+	}
+	if d := tr.throttleDelay(now.Add(time.Minute)); d != 0 {
+		t.Errorf("expected no delay after the reset, got %s", d)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		value  string
+		want   time.Duration
+		wantOk bool
+	}{
+		{"", 0, false},
+		{"7", 7 * time.Second, true},
+		{"Wed, 01 Jan 2020 00:00:30 GMT", 30 * time.Second, true},
+		{"Tue, 31 Dec 2019 23:59:00 GMT", 0, true},
+		{"soon", 0, false},
+	}
+
+	for _, tc := range cases {
+		d, ok := parseRetryAfter(tc.value, now)
+		if d != tc.want || ok != tc.wantOk {
+			t.Errorf("parseRetryAfter(%q): expected %s %t, got %s %t", tc.value, tc.want, tc.wantOk, d, ok)
+		}
+	}
+}
+
+// Returns a server that responds after the delay, or when the request is
+// cancelled.
+func newSlowServer(t *testing.T, delay time.Duration) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "site"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestTimeoutTransport(t *testing.T) {
+	server := newSlowServer(t, 3*time.Second)
+	config := Config{Token: "token", BaseURL: server.URL, RequestTimeout: 200 * time.Millisecond}
+	metaRaw, err := config.Client(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := metaRaw.(*Meta)
+
+	// The runtime ignores the timeout of operations with a context, so the
+	// transport has to limit the context.
+	params := operations.NewGetSiteParamsWithContext(context.Background())
+	params.SiteID = "site"
+	start := time.Now()
+	_, err = meta.Netlify.Operations.GetSite(params, meta.AuthInfo)
+	if err == nil {
+		t.Fatal("expected the request to time out")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the request to time out after 200ms, took %s", elapsed)
+	}
+}
+
+// Resources pass their own context, bounded by the resource timeout, to every
+// operation. The request timeout must still apply to each request.
+func TestTimeoutTransport_resourceContext(t *testing.T) {
+	server := newSlowServer(t, 3*time.Second)
+	config := Config{Token: "token", BaseURL: server.URL, RequestTimeout: 200 * time.Millisecond}
+	meta, err := config.Client(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	r := resourceDnsZone()
+	d := r.TestResourceData()
+	d.SetId("zone")
+
+	start := time.Now()
+	diags := r.ReadContext(ctx, d, meta)
+	if !diags.HasError() {
+		t.Fatal("expected the read to time out")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the read to time out after 200ms, took %s", elapsed)
+	}
+}